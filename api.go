@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"test/evaluator"
+)
+
+// JSON请求体参数，数值和字符串均可；数组按逗号拼接，如 "feed_order": [2,3,1]
+type jsonSource map[string]any
+
+func (s jsonSource) Value(name string) string {
+	switch v := s[name].(type) {
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, x := range v {
+			parts[i] = jsonSource{name: x}.Value(name)
+		}
+		return strings.Join(parts, ",")
+	}
+	return ""
+}
+
+// 以JSON格式输出响应
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, map[string]string{"error": msg})
+}
+
+// POST /api/v1/evaluate：字段与页面表单一致，返回完整评估结果
+func apiEvaluateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持POST请求")
+		return
+	}
+
+	var body jsonSource
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "请求体不是合法的JSON: "+err.Error())
+		return
+	}
+
+	in, err := parseInput(body)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := evaluator.Evaluate(in)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	operator := body.Value("operator")
+	rec, err := saveEvaluation(operator, in, result)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "保存历史记录失败: "+err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, PageData{
+		Time:        rec.Time.Format("2006-01-02 15:04:05"),
+		Operator:    operator,
+		RecordID:    rec.ID,
+		PlantResult: result,
+		Trends:      recentTrends(trendDays),
+	})
+}
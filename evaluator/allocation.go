@@ -0,0 +1,71 @@
+package evaluator
+
+import "fmt"
+
+// 按各效当前（结垢后）能力分配总有效温差，使各效蒸发量相等（负荷均衡）。
+// 单位温差蒸发能力 k_i = Qset_i/DtSet_i × Health_i（健康度封顶为1，无法识别浓度的效按1计），
+// 总有效温差 ΔT = 生蒸汽温度 − 冷凝器温度 − ΣBPE_i，令 k_i·Δt_i 相等得 Δt_i = ΔT·(1/k_i)/Σ(1/k_j)。
+// 多效串联时二次蒸汽逐效传递，整体能力受最小一效限制（效数 × min k_i·Δt_i，与修正推荐同一口径），负荷均衡时最大。
+// 需要生蒸汽温度（生蒸汽压力或I效加热蒸汽温度）和冷凝器温度（输入值或末效汽室压力）。
+func allocateTemperatures(data *PlantResult) {
+	n := len(data.Effects)
+	if n == 0 {
+		return
+	}
+
+	first, last := data.Effects[0], data.Effects[n-1]
+	switch {
+	case data.SteamPressure > 0:
+		data.AllocSteamTemp = data.SteamTemp
+	case first.SteamTemp > 0:
+		data.AllocSteamTemp = first.SteamTemp
+	}
+	switch {
+	case data.CondenserTemp > 0:
+		data.AllocCondTemp = data.CondenserTemp
+	case last.VaporPressure > 0:
+		data.AllocCondTemp = last.VaporTemp
+	}
+	if data.AllocSteamTemp == 0 || data.AllocCondTemp == 0 {
+		return
+	}
+
+	k := make([]float64, n)
+	var totalBPE, sumInv float64
+	for i, e := range data.Effects {
+		totalBPE += e.BPE
+		data.TotalDtSet += e.DtSet
+		if e.DtSet > 0 {
+			k[i] = fouledCapacity(e) / e.DtSet
+		}
+		if k[i] <= 0 {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("%s：当前蒸发能力为0，无法分配温差", e.Name))
+			return
+		}
+		sumInv += 1 / k[i]
+	}
+
+	avail := data.AllocSteamTemp - data.AllocCondTemp - totalBPE
+	if avail <= 0 {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("生蒸汽温度 %.1f℃ − 冷凝器温度 %.1f℃ − ΣBPE %.2f℃ 不大于0，无法分配温差",
+			data.AllocSteamTemp, data.AllocCondTemp, totalBPE))
+		return
+	}
+	data.AvailableDt = avail
+
+	plan := make([]float64, n)
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		e.QsetPlan = k[i] * e.DtSet
+		e.DtOpt = avail / k[i] / sumInv
+		e.QsetOpt = k[i] * e.DtOpt
+		data.TotalQsetPlan += e.QsetPlan
+		data.TotalQsetOpt += e.QsetOpt
+		plan[i] = e.QsetPlan
+	}
+	_, data.PlanCapacity = seriesCapacity(plan)
+
+	if data.TotalDtSet > avail+DtTolerance {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("计划温差之和 %.1f℃ 超过总有效温差 %.1f℃", data.TotalDtSet, avail))
+	}
+}
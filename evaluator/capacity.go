@@ -0,0 +1,104 @@
+package evaluator
+
+// 单效能力差值小于此值 t/h 视为各效能力均衡，不存在瓶颈
+const capacityTolerance = 1e-6
+
+// 按各效实测健康度修正投料推荐，并识别限制整体能力的瓶颈效。
+// 多效串联时各效二次蒸汽逐效传递、蒸发量基本相同，整体脱水能力受单效能力最小的一效限制：
+// 串联能力 = 效数 × min(Qset_i × Health_i)，健康度超过1（超负荷）按1计，健康度未知的效按1计。
+// 修正比例只反映结垢：当前串联能力 / 清洁串联能力（效数 × min Qset_i），
+// 修正推荐 = 名义推荐 × 修正比例，各效设计能力不均衡不计入，设备清洁时与名义推荐相同。
+func computeAdjusted(data *PlantResult) {
+	if data.TotalQset <= 0 {
+		return
+	}
+	caps := make([]float64, len(data.Effects))
+	clean := make([]float64, len(data.Effects))
+	for i, e := range data.Effects {
+		caps[i], clean[i] = fouledCapacity(e), e.Qset
+		if e.Qset <= 0 {
+			caps[i], clean[i] = -1, -1
+		}
+	}
+	limiting, capacity := seriesCapacity(caps)
+	if limiting < 0 {
+		return
+	}
+	_, cleanCapacity := seriesCapacity(clean)
+
+	data.CleanCapacity, data.SeriesCapacity = cleanCapacity, capacity
+	data.AdjustedRatio = capacity / cleanCapacity
+	data.AdjustedQset = data.TotalQset * data.AdjustedRatio
+	if data.TheoreticalMax > 0 {
+		data.AdjustedMax = data.TheoreticalMax * data.AdjustedRatio
+		data.AdjustedLow = data.AdjustedMax * data.Spec.LowFactor
+		data.AdjustedHigh = data.AdjustedMax * data.Spec.HighFactor
+		data.AdjustedSuggest = data.AdjustedMax * data.Spec.SuggestFactor
+	}
+	if cleanCapacity-capacity <= capacityTolerance {
+		return
+	}
+
+	// 结垢使串联能力低于清洁串联能力时标记瓶颈效，损失按清洁串联能力计
+	e := &data.Effects[limiting]
+	e.Bottleneck = true
+	data.LimitingEffect, data.LimitingCapacity = e.No, caps[limiting]
+	data.LostWater = cleanCapacity - capacity
+	data.LostFeed = data.TheoreticalMax - data.AdjustedMax
+	cleanGains(data, caps, cleanCapacity)
+}
+
+// 逐效假设单独清洗（健康度恢复为1），按清洗后的单效能力重新计算串联能力，
+// 记录各效可恢复的脱水能力和投料量（按清洁串联能力折算），并选出恢复最多的一效
+func cleanGains(data *PlantResult, caps []float64, cleanCapacity float64) {
+	_, capacity := seriesCapacity(caps)
+
+	var best float64
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		if e.Unknown || e.Qset <= 0 {
+			continue
+		}
+		saved := caps[i]
+		caps[i] = e.Qset
+		_, cleaned := seriesCapacity(caps)
+		caps[i] = saved
+		gain := cleaned - capacity
+		if gain <= capacityTolerance {
+			continue
+		}
+		e.CleanGainWater = gain
+		e.CleanGainFeed = data.TheoreticalMax * gain / cleanCapacity
+		if gain > best {
+			best, data.BestCleaning = gain, e.No
+		}
+	}
+}
+
+// 单效当前（结垢后）能力 Qset × Health，健康度封顶为1，健康度未知的效按1计
+func fouledCapacity(e EffectData) float64 {
+	if e.Unknown {
+		return e.Qset
+	}
+	return e.Qset * min(max(e.Health, 0), 1)
+}
+
+// 串联整体能力 = 效数 × 最小单效能力，返回最小一效的下标；能力为负的效不参与（也不计入效数），
+// 没有可用的效时返回 -1, 0
+func seriesCapacity(caps []float64) (limiting int, capacity float64) {
+	limiting = -1
+	n := 0
+	for i, c := range caps {
+		if c < 0 {
+			continue
+		}
+		n++
+		if limiting < 0 || c < caps[limiting] {
+			limiting = i
+		}
+	}
+	if limiting < 0 {
+		return -1, 0
+	}
+	return limiting, float64(n) * caps[limiting]
+}
@@ -0,0 +1,77 @@
+package evaluator
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSeriesCapacity(t *testing.T) {
+	for _, c := range []struct {
+		name     string
+		caps     []float64
+		limiting int
+		capacity float64
+	}{
+		{"三效", []float64{2, 1.5, 1}, 2, 3},
+		{"相等", []float64{1, 1, 1}, 0, 3},
+		{"含能力为0的效", []float64{2, 0, 1}, 1, 0},
+		{"不参与的效不计入效数", []float64{2, -1, 1}, 2, 2},
+		{"没有可用的效", []float64{-1, -1}, -1, 0},
+	} {
+		limiting, capacity := seriesCapacity(c.caps)
+		if limiting != c.limiting || math.Abs(capacity-c.capacity) > 1e-12 {
+			t.Errorf("%s: seriesCapacity(%v) = %d, %g, want %d, %g", c.name, c.caps, limiting, capacity, c.limiting, c.capacity)
+		}
+	}
+}
+
+// 三效 Qset 为 2、1.5、1 t/h，理论最大投料量 9 t/h
+func capacityResult(health ...float64) *PlantResult {
+	data := &PlantResult{TotalQset: 4.5, TheoreticalMax: 9, Spec: DefaultSpec()}
+	for i, q := range []float64{2, 1.5, 1} {
+		data.Effects = append(data.Effects, EffectData{No: i + 1, Qset: q, Health: health[i]})
+	}
+	return data
+}
+
+func TestComputeAdjustedClean(t *testing.T) {
+	// 设备清洁（健康度均不低于1）时设计能力不均衡不算损失，修正推荐与名义推荐相同
+	data := capacityResult(1.2, 1, 1)
+	computeAdjusted(data)
+	if data.LimitingEffect != 0 || data.LostWater != 0 || data.BestCleaning != 0 {
+		t.Errorf("清洁设备不应有瓶颈：LimitingEffect = %d, LostWater = %g, BestCleaning = %d", data.LimitingEffect, data.LostWater, data.BestCleaning)
+	}
+	if data.AdjustedRatio != 1 || data.AdjustedQset != data.TotalQset || data.AdjustedMax != data.TheoreticalMax {
+		t.Errorf("AdjustedRatio = %g, AdjustedQset = %g, AdjustedMax = %g", data.AdjustedRatio, data.AdjustedQset, data.AdjustedMax)
+	}
+	for _, e := range data.Effects {
+		if e.Bottleneck {
+			t.Errorf("%d效不应标记为瓶颈", e.No)
+		}
+	}
+}
+
+func TestComputeAdjustedCleanGains(t *testing.T) {
+	// 单效能力 2×0.4=0.8、1.5×0.6=0.9、1×1=1：清洁串联能力 3，当前 2.4
+	data := capacityResult(0.4, 0.6, 1)
+	computeAdjusted(data)
+	if data.CleanCapacity != 3 || math.Abs(data.SeriesCapacity-2.4) > 1e-12 {
+		t.Fatalf("CleanCapacity = %g, SeriesCapacity = %g, want 3, 2.4", data.CleanCapacity, data.SeriesCapacity)
+	}
+	if data.LimitingEffect != 1 || !data.Effects[0].Bottleneck || math.Abs(data.LostWater-0.6) > 1e-12 {
+		t.Errorf("LimitingEffect = %d, LostWater = %g, want 1, 0.6", data.LimitingEffect, data.LostWater)
+	}
+	if math.Abs(data.LostFeed-9*0.6/3) > 1e-12 {
+		t.Errorf("LostFeed = %g, want %g", data.LostFeed, 9*0.6/3)
+	}
+	// 清洗I效后最小为 0.9 → 2.7，恢复 0.3；清洗II效仍受I效限制，恢复 0；III效未结垢
+	want := []float64{0.3, 0, 0}
+	for i, e := range data.Effects {
+		if math.Abs(e.CleanGainWater-want[i]) > 1e-12 || math.Abs(e.CleanGainFeed-9*want[i]/3) > 1e-12 {
+			t.Errorf("%d效 CleanGainWater = %g, CleanGainFeed = %g, want %g, %g", e.No, e.CleanGainWater, e.CleanGainFeed, want[i], 9*want[i]/3)
+		}
+	}
+	if data.BestCleaning != 1 {
+		t.Errorf("BestCleaning = %d, want 1", data.BestCleaning)
+	}
+}
@@ -0,0 +1,48 @@
+package evaluator
+
+import "test/steam"
+
+// 计算蒸汽经济性（需提供生蒸汽流量）。
+// 理论经济性按多效、无热损失、不计显热估算（与进料流程无关）：每效二次蒸汽全部在下一效冷凝，
+// 1 t 生蒸汽在第i效产生 r_s/r_i t 二次蒸汽，故理论经济性 = Σ r_s/r_i。
+// 生蒸汽温度取生蒸汽压力对应的饱和温度，未提供压力时取I效加热蒸汽温度，
+// 仍未知时按I效出料温度 + 计划温差估算。
+func computeEconomy(data *PlantResult) {
+	for _, e := range data.Effects {
+		data.TotalQrun += e.Qrun
+	}
+	data.ProductFlow = data.ActualFlow - data.TotalQrun
+
+	if len(data.Effects) == 0 || data.SteamFlow <= 0 {
+		return
+	}
+
+	first := data.Effects[0]
+	steamTemp := data.SteamTemp
+	switch {
+	case steamTemp > 0:
+	case first.SteamTemp > 0:
+		steamTemp = first.SteamTemp
+	default:
+		steamTemp = first.TempOut + first.DtSet
+	}
+	data.SteamLatent = steam.LatentHeat(steamTemp)
+	if data.SteamTemp == 0 {
+		data.SteamTemp = steamTemp
+	}
+
+	for _, e := range data.Effects {
+		if e.Latent > 0 {
+			data.TheoreticalEconomy += data.SteamLatent / e.Latent
+		}
+	}
+
+	data.SteamEconomy = data.TotalQrun / data.SteamFlow
+	if data.ProductFlow > 0 {
+		data.SpecificSteam = data.SteamFlow / data.ProductFlow
+	}
+	if data.TheoreticalEconomy > 0 {
+		data.TheoreticalSteam = data.TotalQrun / data.TheoreticalEconomy
+		data.EconomyRatio = data.SteamEconomy / data.TheoreticalEconomy
+	}
+}
@@ -0,0 +1,433 @@
+// Package evaluator 三效蒸发加热室健康度评估的计算核心，不依赖HTTP，可供页面、批处理和其他服务直接调用。
+package evaluator
+
+import (
+	"fmt"
+
+	"test/property"
+	"test/steam"
+)
+
+// 热负荷(kW) → 蒸发能力(t/h) 的转换，r 为汽化潜热 kJ/kg
+func heatLoadToEvaporation(q_kW, r float64) float64 {
+	return q_kW * 3600.0 / (r * 1000.0)
+}
+
+// EffectInput 单效输入参数
+type EffectInput struct {
+	Qnom     float64 // 厂家预设换热能力 kW
+	DtDesign float64 // 预设温差 ℃
+	DtSet    float64 // 计划温差 ℃
+	TempOut  float64 // 出料温度 ℃
+	DensOut  float64 // 出料密度 g/cm³
+
+	SteamTemp     float64 // 加热蒸汽温度 ℃，可选，为0时取上一效二次蒸汽温度
+	VaporPressure float64 // 汽室（二次蒸汽）绝对压力 kPa，可选
+	Area          float64 // 加热室换热面积 m²，可选
+	FeedShare     float64 // 平流时本效的原料分配比例（各效相对值），为0时按1计
+}
+
+// PlantInput 一次评估所需的全部输入
+type PlantInput struct {
+	Property   string        // 物性包名称，为空时使用默认物性包
+	Spec       ProductSpec   // 产品规格（目标浓度、投料推荐系数），未填写的项取默认值
+	FeedConc   float64       // 进料浓度，单位见 FeedConcUnit
+	ActualFlow float64       // 实际进料流量，t/h 或 m³/h，见 FlowBasis
+	FlowBasis  FlowBasis     // 流量计量基准，为空时为质量流量
+	Effects    []EffectInput // 各效参数，按蒸汽流向排列（I效由生蒸汽加热）
+
+	FeedArrangement FeedArrangement // 进料流程，为空时为顺流
+	FeedOrder       []int           // 错流时料液流经各效的顺序（效序号），如 2,3,1
+
+	SteamFlow     float64 // 进入I效的生蒸汽流量 t/h，可选
+	SteamPressure float64 // 生蒸汽绝对压力 kPa，可选
+	CondenserTemp float64 // 末效冷凝器温度 ℃，可选，为0时取末效汽室压力对应的饱和温度
+
+	CrystalMargin float64 // 结晶预警裕量（相对饱和浓度的比例），为0时使用 DefaultCrystalMargin
+
+	FeedConcUnit   property.Unit // 进料浓度单位，为空时为水合物质量分数%
+	FeedConcManual bool          // 强制使用手动输入的进料浓度，不由进料温度和密度识别
+	FeedTemp       float64       // 进料温度 ℃，可选，为0时按 DefaultFeedTemp
+	FeedDensity    float64       // 进料密度 g/cm³，可选，为0时由密度表估算
+	DisplayUnit    property.Unit // 结果显示单位，为空时为水合物质量分数%
+}
+
+// EffectData 单效数据：输入参数及计算结果
+type EffectData struct {
+	No             int             // 序号（从1开始）
+	Name           string          // 名称，如 "II效"
+	Qnom           float64         // 厂家预设换热能力 kW
+	DtDesign       float64         // 预设温差 ℃
+	Latent         float64         // 沸腾温度（出料温度）下水的汽化潜热 kJ/kg
+	Qset           float64         // 理论蒸发能力 t/h
+	DtSet          float64         // 计划温差 ℃
+	TempOut        float64         // 出料温度 ℃
+	DensOut        float64         // 出料密度 g/cm³
+	ConcOut        float64         // 自动识别浓度 %
+	ConcOutDisplay float64         // 自动识别浓度（显示单位）
+	Domain         property.Domain // 浓度识别适用范围
+	Warning        string          // 浓度识别提示，如 "密度超出表格范围，浓度已截断"
+	FeedShare      float64         // 平流原料分配比例
+	FlowIn         float64         // 本效进料流量 t/h
+	ConcIn         float64         // 本效进料浓度 %
+	InletTemp      float64         // 本效进料温度 ℃：上一效出料温度，首效和平流时为原料温度
+	Qrun           float64         // 实际蒸发能力 t/h（含闪蒸）
+	Flash          float64         // 进料由 InletTemp 降至出料温度产生的闪蒸量 t/h
+	ChamberQrun    float64         // 加热室蒸发量 Qrun − Flash t/h
+	Health         float64         // 加热室健康度 (Qrun − Flash)/Qset
+	GrossHealth    float64         // 未扣除闪蒸的健康度 Qrun/Qset
+	Status         string          // 状态
+	Unknown        bool            // 本效或上游效浓度无法识别，蒸发量和健康度未知
+
+	SteamTemp     float64 // 加热蒸汽温度 ℃（输入值，0表示未提供）
+	VaporPressure float64 // 汽室绝对压力 kPa（输入值，0表示未提供）
+	VaporTemp     float64 // 二次蒸汽饱和温度 ℃，未提供压力时按出料温度扣除沸点升高估算
+	BPE           float64 // 沸点升高 ℃
+	BoilTemp      float64 // 溶液沸点 ℃，未提供压力时取出料温度
+	HeatingTemp   float64 // 参与有效温差计算的加热蒸汽温度 ℃，0表示未知
+	EffectiveDt   float64 // 有效传热温差 = 加热蒸汽温度 − 溶液沸点 ℃
+	DtChecked     bool    // 是否具备检查计划温差的条件
+	DtAchievable  bool    // 计划温差是否可以实现
+
+	Area    float64 // 换热面积 m²（输入值，0表示未提供）
+	UDt     float64 // 计算实际传热系数所用温差 ℃：有效温差，无法计算时取计划温差
+	UActual float64 // 实际总传热系数 W/(m²·K)
+	UClean  float64 // 清洁（设计）总传热系数 W/(m²·K)
+	Rf      float64 // 污垢热阻 m²·K/W
+
+	Solubility      float64 // 出料温度下的饱和浓度 %
+	Supersaturation float64 // 过饱和度 ConcOut/Solubility，≥1 表示已饱和
+	CrystalRisk     bool    // 是否接近或超过饱和，加热管内有结晶风险
+
+	Bottleneck     bool    // 是否为限制整体能力的效
+	CleanGainWater float64 // 单独清洗本效（健康度恢复为1）后可恢复的脱水能力 t/h
+	CleanGainFeed  float64 // 单独清洗本效后可恢复的投料量 t/h
+
+	DtOpt    float64 // 建议温差 ℃（负荷均衡分配）
+	QsetPlan float64 // 按计划温差、当前健康度的蒸发能力 t/h
+	QsetOpt  float64 // 按建议温差、当前健康度的蒸发能力 t/h
+}
+
+// PlantResult 评估结果，与页面展示的数据一一对应
+type PlantResult struct {
+	Property       string       // 物性包名称
+	PropertyLabel  string       // 物性包显示名称
+	FeedConc       float64      // 进料浓度（水合物质量分数%，质量平衡基准）
+	Spec           ProductSpec  // 本次评估使用的产品规格
+	TargetConc     float64      // 目标浓度
+	TotalQset      float64      // 系统峰值脱水能力
+	TheoreticalMax float64      // 理论最大投料量
+	RecommendLow   float64      // 推荐下限
+	RecommendHigh  float64      // 推荐上限
+	SuggestFlow    float64      // 建议设定值
+	ActualFlow     float64      // 实际进料质量流量 t/h（体积流量已按进料密度换算）
+	FlowInput      float64      // 用户输入的流量（FlowBasis 单位）
+	FlowBasis      FlowBasis    // 流量计量基准
+	Effects        []EffectData // 各效数据，按蒸汽流向排列
+
+	FeedArrangement FeedArrangement // 进料流程
+	FeedOrder       []int           // 输入的错流顺序（回显）
+	FlowOrder       []int           // 料液流经各效的顺序（效序号），页面按此顺序排列各效；输入有误时为编号顺序
+
+	Warnings []string // 需要提醒操作员的问题，如传感器数值超出物性数据范围
+
+	SteamFlow          float64 // 生蒸汽流量 t/h（输入值，0表示未提供）
+	SteamPressure      float64 // 生蒸汽绝对压力 kPa（输入值，0表示未提供）
+	SteamTemp          float64 // 生蒸汽饱和温度 ℃
+	SteamLatent        float64 // 生蒸汽汽化潜热 kJ/kg
+	TotalQrun          float64 // 各效实际蒸发量之和 t/h
+	ProductFlow        float64 // 完成液流量 = 实际流量 − ΣQrun t/h
+	SteamEconomy       float64 // 实际蒸汽经济性 ΣQrun/生蒸汽流量
+	SpecificSteam      float64 // 吨完成液生蒸汽消耗 t/t
+	TheoreticalEconomy float64 // 理论蒸汽经济性（多效、无热损失、不计显热）
+	TheoreticalSteam   float64 // 产生 ΣQrun 所需的理论生蒸汽量 t/h
+	EconomyRatio       float64 // 实际/理论蒸汽经济性
+
+	CrystalMargin float64  // 结晶预警裕量
+	CrystalRisks  []string // 结晶风险提示，页面醒目显示
+
+	FeedConcInput     float64        // 用户输入的进料浓度（FeedConcUnit 单位）
+	FeedConcUnit      property.Unit  // 进料浓度单位
+	FeedConcManual    bool           // 是否强制手动输入
+	FeedConcSource    FeedConcSource // 实际使用的进料浓度来源
+	FeedTemp          float64        // 进料温度 ℃（输入值，0表示未提供）
+	FeedDensityInput  float64        // 进料密度输入值 g/cm³，0表示未提供
+	FeedDensity       float64        // 进料密度 g/cm³：输入值或由密度表估算
+	DisplayUnit       property.Unit  // 结果显示单位
+	DisplayLabel      string         // 结果显示单位名称，如 "Co g/L"
+	FeedConcDisplay   float64        // 进料浓度（显示单位）
+	TargetConcDisplay float64        // 目标浓度（显示单位）
+
+	LimitingEffect   int     // 单效能力最小、限制整体能力的瓶颈效序号，0表示各效能力均衡、无瓶颈
+	LimitingCapacity float64 // 瓶颈效当前能力 Qset × Health（健康度封顶为1） t/h
+	CleanCapacity    float64 // 清洁串联能力 = 效数 × min(Qset_i) t/h
+	SeriesCapacity   float64 // 当前串联能力 = 效数 × min(Qset_i × Health_i)，与 PlanCapacity 同一口径 t/h
+	AdjustedRatio    float64 // 结垢修正比例 = 当前串联能力 / 清洁串联能力
+	AdjustedQset     float64 // 按健康度修正的系统脱水能力 = ΣQset × 修正比例 t/h
+	AdjustedMax      float64 // 按健康度修正的最大投料量 t/h
+	AdjustedLow      float64 // 按健康度修正的推荐下限 t/h
+	AdjustedHigh     float64 // 按健康度修正的推荐上限 t/h
+	AdjustedSuggest  float64 // 按健康度修正的建议设定值 t/h
+	LostWater        float64 // 因结垢损失的串联脱水能力 = 清洁串联能力 − 当前串联能力 t/h
+	LostFeed         float64 // 因结垢损失的投料量 = 理论最大投料量 × 损失脱水能力 / 清洁串联能力 t/h
+	BestCleaning     int     // 单独清洗后恢复能力最多的效序号，0表示无需清洗
+
+	CondenserTemp  float64 // 末效冷凝器温度 ℃（输入值，0表示未提供）
+	AllocSteamTemp float64 // 温差分配使用的生蒸汽温度 ℃
+	AllocCondTemp  float64 // 温差分配使用的冷凝器温度 ℃
+	AvailableDt    float64 // 总有效温差 = 生蒸汽温度 − 冷凝器温度 − ΣBPE ℃，0表示无法分配
+	TotalDtSet     float64 // 计划温差之和 ℃
+	TotalQsetPlan  float64 // 计划温差下各效蒸发能力之和（按当前健康度） t/h
+	TotalQsetOpt   float64 // 建议温差下各效蒸发能力之和（按当前健康度） t/h
+	PlanCapacity   float64 // 计划温差下串联整体能力 = 效数 × 最小单效能力 t/h
+}
+
+var romanNumerals = []struct {
+	value  int
+	symbol string
+}{
+	{1000, "M"}, {900, "CM"}, {500, "D"}, {400, "CD"}, {100, "C"}, {90, "XC"},
+	{50, "L"}, {40, "XL"}, {10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"},
+}
+
+// EffectName 第n效（从1开始）的名称，沿用罗马数字写法：I效、II效、III效、IV效……
+func EffectName(n int) string {
+	if n <= 0 {
+		return fmt.Sprintf("第%d效", n)
+	}
+	s := ""
+	for _, r := range romanNumerals {
+		for n >= r.value {
+			s += r.symbol
+			n -= r.value
+		}
+	}
+	return s + "效"
+}
+
+// Validate 检查输入是否可以参与计算（除数不能为0，物理量不能为负）
+func (in PlantInput) Validate() error {
+	if len(in.Effects) == 0 {
+		return fmt.Errorf("至少需要一效")
+	}
+	if err := in.Spec.Validate(); err != nil {
+		return err
+	}
+	if in.FeedConc < 0 {
+		return fmt.Errorf("进料浓度不能为负")
+	}
+	if in.ActualFlow < 0 {
+		return fmt.Errorf("实际流量不能为负")
+	}
+	if in.SteamFlow < 0 || in.SteamPressure < 0 {
+		return fmt.Errorf("生蒸汽流量和压力不能为负")
+	}
+	if in.FeedTemp < 0 || in.FeedDensity < 0 {
+		return fmt.Errorf("进料温度和密度不能为负")
+	}
+	if in.CrystalMargin < 0 || in.CrystalMargin >= 1 {
+		return fmt.Errorf("结晶预警裕量应在0～1之间")
+	}
+	for i, e := range in.Effects {
+		name := EffectName(i + 1)
+		if e.Qnom <= 0 {
+			return fmt.Errorf("%s厂家预设换热能力必须大于0", name)
+		}
+		if e.DtDesign <= 0 {
+			return fmt.Errorf("%s预设温差必须大于0", name)
+		}
+		if e.DtSet < 0 {
+			return fmt.Errorf("%s计划温差不能为负", name)
+		}
+		if e.DensOut <= 0 {
+			return fmt.Errorf("%s出料密度必须大于0", name)
+		}
+		if !steam.InRange(e.TempOut) {
+			return fmt.Errorf("%s出料温度 %g℃ 超出水蒸气物性适用范围（0.01～373.9℃）", name, e.TempOut)
+		}
+		if e.FeedShare < 0 {
+			return fmt.Errorf("%s原料分配比例不能为负", name)
+		}
+	}
+	return nil
+}
+
+// 健康度分级阈值
+const (
+	OverloadHealth        = 1.1 // 高于此值为超负荷运行
+	GoodHealth            = 0.9 // 高于此值为运行良好
+	ModerateFoulingHealth = 0.7 // 不高于此值进入中度结垢
+	SevereFoulingHealth   = 0.5 // 不高于此值进入严重结垢
+)
+
+// 蒸发量和健康度未知的效的状态
+const (
+	StatusInvalid  = "无法识别浓度"    // 本效出料浓度无法识别
+	StatusUpstream = "上游效浓度无法识别" // 串联流程中上游效浓度无法识别，本效进料未知
+)
+
+// Classify 按健康度划分加热室状态
+func Classify(health float64) string {
+	switch {
+	case health > OverloadHealth:
+		return "超负荷运行"
+	case health > GoodHealth:
+		return "运行良好"
+	case health > ModerateFoulingHealth:
+		return "轻微结垢"
+	case health > SevereFoulingHealth:
+		return "中度结垢"
+	default:
+		return "严重结垢"
+	}
+}
+
+// Evaluate 计算投料推荐和各效健康度。
+// 输入非法时返回错误，此时结果中只回填了输入参数，便于页面原样回显。
+func Evaluate(in PlantInput) (PlantResult, error) {
+	pkg, ok := property.Get(in.Property)
+	in.Spec = in.Spec.withDefaults()
+	if ok && in.Spec.TargetConc == 0 {
+		in.Spec.TargetConc = pkg.DefaultTargetConc()
+	}
+
+	data := PlantResult{
+		Property:   in.Property,
+		Spec:       in.Spec,
+		TargetConc: in.Spec.TargetConc,
+		FeedConc:   in.FeedConc,
+		ActualFlow: in.ActualFlow,
+		Effects:    make([]EffectData, len(in.Effects)),
+
+		FeedArrangement: in.FeedArrangement,
+		FeedOrder:       in.FeedOrder,
+
+		SteamFlow:     in.SteamFlow,
+		SteamPressure: in.SteamPressure,
+		CondenserTemp: in.CondenserTemp,
+		CrystalMargin: in.CrystalMargin,
+
+		FlowInput:        in.ActualFlow,
+		FlowBasis:        in.FlowBasis,
+		FeedConcInput:    in.FeedConc,
+		FeedConcUnit:     in.FeedConcUnit,
+		FeedConcManual:   in.FeedConcManual,
+		FeedTemp:         in.FeedTemp,
+		FeedDensityInput: in.FeedDensity,
+		FeedDensity:      in.FeedDensity,
+		DisplayUnit:      in.DisplayUnit,
+	}
+	if data.FlowBasis == "" {
+		data.FlowBasis = FlowMass
+	}
+	if data.FeedArrangement == "" {
+		data.FeedArrangement, in.FeedArrangement = FeedForward, FeedForward
+	}
+	if data.FeedConcUnit == "" {
+		data.FeedConcUnit = property.UnitHydrate
+	}
+	if data.DisplayUnit == "" {
+		data.DisplayUnit = property.UnitHydrate
+	}
+	if data.CrystalMargin == 0 {
+		data.CrystalMargin = DefaultCrystalMargin
+	}
+	for i, e := range in.Effects {
+		data.FlowOrder = append(data.FlowOrder, i+1)
+		data.Effects[i] = EffectData{
+			No:       i + 1,
+			Name:     EffectName(i + 1),
+			Qnom:     e.Qnom,
+			DtDesign: e.DtDesign,
+			DtSet:    e.DtSet,
+			TempOut:  e.TempOut,
+			DensOut:  e.DensOut,
+
+			SteamTemp:     e.SteamTemp,
+			VaporPressure: e.VaporPressure,
+			Area:          e.Area,
+			FeedShare:     e.FeedShare,
+		}
+	}
+	if !ok {
+		return data, fmt.Errorf("未知物性包: %s", in.Property)
+	}
+	data.Property, data.PropertyLabel = pkg.Name(), pkg.Label()
+	if in.Spec.Property != "" && in.Spec.Property != pkg.Name() {
+		return data, fmt.Errorf("产品规格 %s 适用于 %s，与所选物性包 %s 不符", in.Spec.Name, in.Spec.Property, pkg.Name())
+	}
+	if err := in.Validate(); err != nil {
+		return data, err
+	}
+	order, err := flowOrder(in.FeedArrangement, in.FeedOrder, len(in.Effects))
+	if err != nil {
+		return data, err
+	}
+	data.FlowOrder = order
+	if err := convertFeed(pkg, &data); err != nil {
+		return data, err
+	}
+	if err := convertFlow(&data); err != nil {
+		return data, err
+	}
+
+	// 第一部分：计算各效理论蒸发能力
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		e.Latent = steam.LatentHeat(e.TempOut)
+		e.Qset = heatLoadToEvaporation(e.Qnom, e.Latent) * (e.DtSet / e.DtDesign)
+		data.TotalQset += e.Qset
+	}
+
+	// 计算理论最大投料量和推荐范围
+	if data.TargetConc > data.FeedConc {
+		concentrationRatio := data.TargetConc / (data.TargetConc - data.FeedConc)
+		data.TheoreticalMax = data.TotalQset * concentrationRatio
+		data.RecommendLow = data.TheoreticalMax * data.Spec.LowFactor
+		data.RecommendHigh = data.TheoreticalMax * data.Spec.HighFactor
+		data.SuggestFlow = data.TheoreticalMax * data.Spec.SuggestFactor
+	}
+
+	// 第二部分：自动识别各效出料浓度（通过双向插值），密度单位统一为g/cm³，直接使用
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		conc := pkg.Conc(e.TempOut, e.DensOut)
+		e.ConcOut, e.Domain, e.Warning = conc.Conc, conc.Domain, conc.Domain.Warning()
+		if e.Warning != "" {
+			data.Warnings = append(data.Warnings, e.Name+"："+e.Warning)
+		}
+	}
+
+	// 按进料流程逐效级联计算实际蒸发量，扣除闪蒸后计算加热室健康度与状态
+	cascade(&data)
+	computeFlash(pkg, &data)
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		switch {
+		case e.Domain == property.Invalid:
+			e.Status = StatusInvalid
+		case e.Unknown:
+			e.Status = StatusUpstream
+		default:
+			if e.Qset > 0 {
+				e.Health = e.ChamberQrun / e.Qset
+				e.GrossHealth = e.Qrun / e.Qset
+			}
+			e.Status = Classify(e.Health)
+		}
+	}
+
+	if data.SteamPressure > 0 {
+		data.SteamTemp = steam.SaturationTemperature(data.SteamPressure)
+	}
+
+	checkTemperatures(pkg, &data)
+	computeFouling(&data)
+	computeEconomy(&data)
+	computeAdjusted(&data)
+	allocateTemperatures(&data)
+	checkSaturation(pkg, &data)
+	convertDisplay(pkg, &data)
+
+	return data, nil
+}
@@ -0,0 +1,43 @@
+package evaluator
+
+import (
+	"math"
+	"testing"
+
+	"test/property"
+)
+
+func testPlantInput() PlantInput {
+	return PlantInput{
+		Property:   property.DefaultName,
+		Spec:       DefaultSpec(),
+		FeedConc:   18,
+		ActualFlow: 55,
+		Effects: []EffectInput{
+			{Qnom: 1200, DtDesign: 25, DtSet: 24, TempOut: 92, DensOut: 1.190},
+			{Qnom: 1000, DtDesign: 22, DtSet: 20, TempOut: 78, DensOut: 1.290},
+			{Qnom: 800, DtDesign: 18, DtSet: 16, TempOut: 62, DensOut: 1.550},
+		},
+	}
+}
+
+func TestCascadeUnknownDownstream(t *testing.T) {
+	in := testPlantInput()
+	in.Effects[1].DensOut = math.Inf(1)
+	res, err := Evaluate(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		unknown bool
+		status  string
+	}{{false, ""}, {true, StatusInvalid}, {true, StatusUpstream}}
+	for i, e := range res.Effects {
+		if e.Unknown != want[i].unknown || (want[i].status != "" && e.Status != want[i].status) {
+			t.Errorf("%s: Unknown = %v, Status = %s", e.Name, e.Unknown, e.Status)
+		}
+		if e.Unknown && (e.Qrun != 0 || e.Health != 0) {
+			t.Errorf("%s: 未知效不应有蒸发量和健康度，Qrun = %g, Health = %g", e.Name, e.Qrun, e.Health)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"test/property"
+)
+
+// FeedArrangement 进料流程。各效始终按蒸汽流向编号（I效由生蒸汽加热），进料流程只决定料液流经各效的顺序。
+type FeedArrangement string
+
+const (
+	FeedForward  FeedArrangement = "forward"  // 顺流：I → N，与蒸汽同向
+	FeedBackward FeedArrangement = "backward" // 逆流：N → I
+	FeedParallel FeedArrangement = "parallel" // 平流：原料按分配比例同时进入各效，各效分别出料
+	FeedMixed    FeedArrangement = "mixed"    // 错流：按自定义顺序流经各效
+)
+
+// Label 进料流程显示名称
+func (a FeedArrangement) Label() string {
+	switch a {
+	case FeedBackward:
+		return "逆流"
+	case FeedParallel:
+		return "平流"
+	case FeedMixed:
+		return "错流"
+	}
+	return "顺流"
+}
+
+// n 效时料液流经各效的顺序（效序号，从1开始）；平流时为各效编号顺序，custom 为错流时的自定义顺序
+func flowOrder(arr FeedArrangement, custom []int, n int) ([]int, error) {
+	order := make([]int, n)
+	switch arr {
+	case FeedForward, FeedParallel:
+		for i := range order {
+			order[i] = i + 1
+		}
+	case FeedBackward:
+		for i := range order {
+			order[i] = n - i
+		}
+	case FeedMixed:
+		if len(custom) != n {
+			return nil, fmt.Errorf("错流进料顺序应包含全部 %d 效，如 2,3,1", n)
+		}
+		seen := make([]bool, n+1)
+		for i, no := range custom {
+			if no < 1 || no > n || seen[no] {
+				return nil, fmt.Errorf("错流进料顺序有误：每效恰好出现一次，效序号为1～%d", n)
+			}
+			seen[no] = true
+			order[i] = no
+		}
+	default:
+		return nil, fmt.Errorf("未知进料流程: %s", arr)
+	}
+	return order, nil
+}
+
+// 按料液流向逐效计算实际蒸发量（各效出料浓度已识别）。
+// 串联流程中每效的进料为上一效的出料；平流时各效进料均为原料，流量按分配比例计。
+// 无法识别浓度的效蒸发量未知，串联流程中其下游各效的进料随之未知，均标记为 Unknown、蒸发量记为0。
+func cascade(data *PlantResult) {
+	if data.FeedArrangement == FeedParallel {
+		var total float64
+		for i := range data.Effects {
+			e := &data.Effects[i]
+			if e.FeedShare == 0 {
+				e.FeedShare = 1
+			}
+			total += e.FeedShare
+		}
+		for i := range data.Effects {
+			e := &data.Effects[i]
+			e.FlowIn, e.ConcIn, e.InletTemp = data.ActualFlow*e.FeedShare/total, data.FeedConc, data.feedTemp()
+			e.Unknown = e.Domain == property.Invalid
+			e.Qrun = evaporation(e)
+		}
+		return
+	}
+
+	flow := data.ActualFlow
+	cin := data.FeedConc
+	tin := data.feedTemp()
+	unknown := false
+	for _, no := range data.FlowOrder {
+		e := &data.Effects[no-1]
+		unknown = unknown || e.Domain == property.Invalid
+		e.Unknown = unknown
+		if unknown {
+			continue
+		}
+		e.FlowIn, e.ConcIn, e.InletTemp = flow, cin, tin
+		e.Qrun = evaporation(e)
+		flow -= e.Qrun
+		cin = e.ConcOut
+		tin = e.TempOut
+	}
+}
+
+// 单效质量平衡：Qrun = 进料流量 × (ConcOut − ConcIn)/ConcOut
+func evaporation(e *EffectData) float64 {
+	if e.Unknown || e.ConcOut <= e.ConcIn || e.ConcOut <= 0 {
+		return 0
+	}
+	return e.FlowIn * (e.ConcOut - e.ConcIn) / e.ConcOut
+}
+
+// 出完成液的效：串联流程为料液流经的最后一效，平流时取末效
+func (data *PlantResult) productEffect() *EffectData {
+	if len(data.FlowOrder) == 0 || data.FeedArrangement == FeedParallel {
+		return &data.Effects[len(data.Effects)-1]
+	}
+	return &data.Effects[data.FlowOrder[len(data.FlowOrder)-1]-1]
+}
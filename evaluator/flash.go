@@ -0,0 +1,22 @@
+package evaluator
+
+import "test/property"
+
+// 计算各效进料闪蒸量：进料温度高于本效出料（沸腾）温度时，显热降低部分使料液自蒸发，
+// 这部分蒸发量不由加热室提供，计算健康度前扣除：
+//
+//	Flash = 进料流量 × cp(进料浓度, 进料温度) × (进料温度 − 出料温度) / r
+//
+// 进料温度低于出料温度时（如冷原料进入首效）不产生闪蒸，记为0。
+func computeFlash(pkg property.Package, data *PlantResult) {
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		e.ChamberQrun = e.Qrun
+		if e.Unknown || e.InletTemp <= e.TempOut || e.Latent <= 0 {
+			continue
+		}
+		cp := pkg.HeatCapacity(e.ConcIn, e.InletTemp)
+		e.Flash = e.FlowIn * cp * (e.InletTemp - e.TempOut) / e.Latent
+		e.ChamberQrun = max(e.Qrun-e.Flash, 0)
+	}
+}
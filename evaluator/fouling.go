@@ -0,0 +1,28 @@
+package evaluator
+
+// 计算各效总传热系数和污垢热阻（需提供换热面积）：
+//
+//	U_actual = Q/(A·Δt)，Q 为加热室蒸发量（扣除闪蒸）对应的热负荷，Δt 取有效温差（无法计算时取计划温差）
+//	U_clean  = Qnom/(A·DtDesign)
+//	Rf       = 1/U_actual − 1/U_clean
+func computeFouling(data *PlantResult) {
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		if e.Area <= 0 {
+			continue
+		}
+
+		e.UClean = e.Qnom * 1000 / (e.Area * e.DtDesign)
+
+		e.UDt = e.DtSet
+		if e.DtChecked && e.EffectiveDt > 0 {
+			e.UDt = e.EffectiveDt
+		}
+		if e.UDt <= 0 || e.ChamberQrun <= 0 {
+			continue
+		}
+		q := e.ChamberQrun * 1000 / 3600 * e.Latent // kW
+		e.UActual = q * 1000 / (e.Area * e.UDt)
+		e.Rf = 1/e.UActual - 1/e.UClean
+	}
+}
@@ -0,0 +1,126 @@
+package evaluator
+
+import (
+	"errors"
+	"math"
+)
+
+// 牛顿迭代参数
+const (
+	newtonMaxIter = 100   // 最大迭代次数
+	newtonTol     = 1e-6  // 收敛判据：残差最大绝对值
+	newtonMinStep = 1e-4  // 阻尼步长下限
+	jacobianStep  = 1e-6  // 数值雅可比的相对扰动
+	pivotEpsilon  = 1e-14 // 主元小于此值视为奇异
+)
+
+// 残差函数不可计算（如浓度超过100%）时返回错误，牛顿法据此缩短步长
+var errInfeasible = errors.New("不可行的迭代点")
+
+// 阻尼牛顿法求解 f(x) = 0，雅可比矩阵按前向差分数值计算。
+// 每步先取完整牛顿步，残差范数不下降或迭代点不可行时步长减半。
+func newton(f func(x []float64) ([]float64, error), x0 []float64) (x []float64, iter int, err error) {
+	x = append([]float64(nil), x0...)
+	fx, err := f(x)
+	if err != nil {
+		return x, 0, err
+	}
+	n := len(x)
+	for iter = 1; iter <= newtonMaxIter; iter++ {
+		if maxAbs(fx) < newtonTol {
+			return x, iter - 1, nil
+		}
+
+		jac := make([][]float64, n)
+		for i := range jac {
+			jac[i] = make([]float64, n)
+		}
+		for j := range x {
+			h := jacobianStep * math.Max(1, math.Abs(x[j]))
+			xj := x[j]
+			x[j] += h
+			fh, err := f(x)
+			if err != nil {
+				x[j] = xj - h
+				if fh, err = f(x); err != nil {
+					x[j] = xj
+					return x, iter, err
+				}
+				h = -h
+			}
+			x[j] = xj
+			for i := range fh {
+				jac[i][j] = (fh[i] - fx[i]) / h
+			}
+		}
+
+		rhs := make([]float64, n)
+		for i := range fx {
+			rhs[i] = -fx[i]
+		}
+		dx, err := solveLinear(jac, rhs)
+		if err != nil {
+			return x, iter, err
+		}
+
+		norm := maxAbs(fx)
+		next := make([]float64, n)
+		for lambda := 1.0; ; lambda /= 2 {
+			if lambda < newtonMinStep {
+				return x, iter, errors.New("牛顿迭代步长过小，无法继续下降")
+			}
+			for i := range x {
+				next[i] = x[i] + lambda*dx[i]
+			}
+			fn, err := f(next)
+			if err == nil && maxAbs(fn) < norm {
+				copy(x, next)
+				fx = fn
+				break
+			}
+		}
+	}
+	return x, newtonMaxIter, errors.New("牛顿迭代未收敛")
+}
+
+// 列主元高斯消元求解 a·x = b，a 和 b 会被修改
+func solveLinear(a [][]float64, b []float64) ([]float64, error) {
+	n := len(b)
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+				p = i
+			}
+		}
+		if math.Abs(a[p][k]) < pivotEpsilon {
+			return nil, errors.New("雅可比矩阵奇异")
+		}
+		a[k], a[p] = a[p], a[k]
+		b[k], b[p] = b[p], b[k]
+		for i := k + 1; i < n; i++ {
+			m := a[i][k] / a[k][k]
+			for j := k; j < n; j++ {
+				a[i][j] -= m * a[k][j]
+			}
+			b[i] -= m * b[k]
+		}
+	}
+	x := make([]float64, n)
+	for i := n - 1; i >= 0; i-- {
+		s := b[i]
+		for j := i + 1; j < n; j++ {
+			s -= a[i][j] * x[j]
+		}
+		x[i] = s / a[i][i]
+	}
+	return x, nil
+}
+
+func maxAbs(v []float64) float64 {
+	var m float64
+	for _, x := range v {
+		m = math.Max(m, math.Abs(x))
+	}
+	return m
+}
@@ -0,0 +1,35 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"test/property"
+)
+
+// 默认结晶预警裕量：出料浓度达到饱和浓度的97%即提示
+const DefaultCrystalMargin = 0.03
+
+// 按物性包溶解度曲线计算各效出料的过饱和度，接近或超过饱和时给出结晶风险提示
+func checkSaturation(pkg property.Package, data *PlantResult) {
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		if e.Domain == property.Invalid {
+			continue
+		}
+		e.Solubility = pkg.SolubilityLimit(e.TempOut)
+		if e.Solubility <= 0 {
+			continue
+		}
+		e.Supersaturation = e.ConcOut / e.Solubility
+		e.CrystalRisk = e.Supersaturation >= 1-data.CrystalMargin
+
+		switch {
+		case e.Supersaturation >= 1:
+			data.CrystalRisks = append(data.CrystalRisks, fmt.Sprintf("%s：出料浓度 %.2f%% 已超过 %.1f℃ 下的饱和浓度 %.2f%%（过饱和度 %.3f），加热管内可能结晶",
+				e.Name, e.ConcOut, e.TempOut, e.Solubility, e.Supersaturation))
+		case e.CrystalRisk:
+			data.CrystalRisks = append(data.CrystalRisks, fmt.Sprintf("%s：出料浓度 %.2f%% 已达 %.1f℃ 下饱和浓度 %.2f%% 的 %.1f%%，接近结晶",
+				e.Name, e.ConcOut, e.TempOut, e.Solubility, e.Supersaturation*100))
+		}
+	}
+}
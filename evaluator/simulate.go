@@ -0,0 +1,345 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"test/property"
+	"test/steam"
+)
+
+// SimEffectInput 稳态模拟的单效输入
+type SimEffectInput struct {
+	Qnom      float64 // 厂家预设换热能力 kW
+	DtDesign  float64 // 预设温差 ℃
+	Health    float64 // 健康度（结垢系数），UA = Qnom/DtDesign × Health，为0时按1计
+	FeedShare float64 // 平流时的原料分配比例（相对值），为0时按1计
+}
+
+// SimInput 稳态模拟输入：给定进料、生蒸汽和冷凝器条件，预测各效出料
+type SimInput struct {
+	Property        string           // 物性包名称，为空时使用默认物性包
+	TargetConc      float64          // 目标浓度 %，为0时使用物性包默认目标浓度，仅用于对比
+	FeedFlow        float64          // 进料质量流量 t/h
+	FeedConc        float64          // 进料浓度（水合物质量分数%）
+	FeedTemp        float64          // 进料温度 ℃，为0时按 DefaultFeedTemp
+	SteamPressure   float64          // 生蒸汽绝对压力 kPa
+	SteamTemp       float64          // 生蒸汽温度 ℃，未提供压力时使用
+	CondenserTemp   float64          // 末效冷凝器温度 ℃
+	FeedArrangement FeedArrangement  // 进料流程，为空时为顺流
+	FeedOrder       []int            // 错流时料液流经各效的顺序
+	Effects         []SimEffectInput // 各效参数，按蒸汽流向排列
+}
+
+// SimEffect 稳态模拟的单效结果
+type SimEffect struct {
+	No          int     // 序号（从1开始）
+	Name        string  // 名称
+	UA          float64 // 传热能力 kW/K
+	HeatingTemp float64 // 加热蒸汽温度 ℃
+	TempOut     float64 // 出料（沸腾）温度 ℃
+	VaporTemp   float64 // 二次蒸汽温度 ℃
+	BPE         float64 // 沸点升高 ℃
+	Duty        float64 // 加热室热负荷 kW
+	Vapor       float64 // 蒸发量 t/h
+	FlowIn      float64 // 进料流量 t/h
+	ConcIn      float64 // 进料浓度 %
+	InletTemp   float64 // 进料温度 ℃
+	FlowOut     float64 // 出料流量 t/h
+	ConcOut     float64 // 出料浓度 %
+	DensOut     float64 // 出料温度下的出料密度 g/cm³（由密度表计算）
+	Solubility  float64 // 出料温度下的饱和浓度 %
+	CrystalRisk bool    // 出料浓度是否接近或超过饱和
+}
+
+// SimResult 稳态模拟结果
+type SimResult struct {
+	Property        string          // 物性包名称
+	PropertyLabel   string          // 物性包显示名称
+	TargetConc      float64         // 目标浓度 %
+	FeedFlow        float64         // 进料质量流量 t/h
+	FeedConc        float64         // 进料浓度 %
+	FeedTemp        float64         // 进料温度 ℃
+	SteamPressure   float64         // 生蒸汽绝对压力 kPa（输入值）
+	SteamTemp       float64         // 生蒸汽温度 ℃
+	CondenserTemp   float64         // 冷凝器温度 ℃
+	FeedArrangement FeedArrangement // 进料流程
+	FlowOrder       []int           // 料液流经各效的顺序
+	Effects         []SimEffect     // 各效结果，按蒸汽流向排列
+
+	SteamFlow        float64  // 生蒸汽消耗 t/h
+	TotalEvaporation float64  // 总蒸发量 t/h
+	ProductFlow      float64  // 完成液流量 t/h
+	ProductConc      float64  // 完成液浓度 %
+	SteamEconomy     float64  // 蒸汽经济性 总蒸发量/生蒸汽
+	Iterations       int      // 牛顿迭代次数
+	Warnings         []string // 提示
+}
+
+// Validate 检查模拟输入
+func (in SimInput) Validate() error {
+	if len(in.Effects) == 0 {
+		return fmt.Errorf("至少需要一效")
+	}
+	if in.FeedFlow <= 0 {
+		return fmt.Errorf("进料流量必须大于0")
+	}
+	if in.FeedConc <= 0 || in.FeedConc >= 100 {
+		return fmt.Errorf("进料浓度应在0～100%%之间")
+	}
+	if in.FeedTemp < 0 || in.SteamPressure < 0 || in.SteamTemp < 0 || in.CondenserTemp < 0 {
+		return fmt.Errorf("温度和压力不能为负")
+	}
+	if in.SteamPressure == 0 && in.SteamTemp == 0 {
+		return fmt.Errorf("需要生蒸汽压力或温度")
+	}
+	if in.CondenserTemp == 0 {
+		return fmt.Errorf("需要冷凝器温度")
+	}
+	for i, e := range in.Effects {
+		name := EffectName(i + 1)
+		if e.Qnom <= 0 || e.DtDesign <= 0 {
+			return fmt.Errorf("%s厂家预设换热能力和预设温差必须大于0", name)
+		}
+		if e.Health < 0 || e.FeedShare < 0 {
+			return fmt.Errorf("%s健康度和原料分配比例不能为负", name)
+		}
+	}
+	return nil
+}
+
+// 稳态模型：未知量 x = [D, V_1..V_N, T_1..T_N]（生蒸汽 t/h、各效蒸发量 t/h、各效沸腾温度 ℃），
+// 各效按蒸汽流向编号，料液按进料流程流经各效。方程（热量单位 kW）：
+//
+//	传热：    UA_i·(Th_i − T_i) = S_i·r(Th_i)，S_1 = D，S_i = V_(i−1)，Th_1 = 生蒸汽温度，Th_i = T_(i−1) − BPE_(i−1)
+//	热量衡算：UA_i·(Th_i − T_i) + L_in,i·cp·(Tin_i − T_i) = V_i·r(T_i − BPE_i)
+//	末效：    T_N − BPE_N = 冷凝器温度
+//
+// 进料显热变化（含闪蒸）由热量衡算计入，忽略热损失、二次蒸汽过热和浓缩热。
+type simModel struct {
+	pkg   property.Package
+	in    SimInput
+	ua    []float64
+	order []int
+	share []float64 // 平流时各效进料流量 t/h
+	ts    float64   // 生蒸汽温度 ℃
+	tf    float64   // 进料温度 ℃
+}
+
+// 按迭代值计算各效状态，浓度超过100%等不可行时返回 errInfeasible
+func (m *simModel) state(x []float64) ([]SimEffect, error) {
+	n := len(m.ua)
+	out := make([]SimEffect, n)
+	liquor := func(i int, flow, conc, tin float64) (float64, float64, error) {
+		e := &out[i]
+		e.FlowIn, e.ConcIn, e.InletTemp = flow, conc, tin
+		e.Vapor, e.TempOut = x[1+i], x[1+n+i]
+		e.FlowOut = flow - e.Vapor
+		if e.FlowOut <= 0 {
+			return 0, 0, errInfeasible
+		}
+		e.ConcOut = flow * conc / e.FlowOut
+		if e.ConcOut <= 0 || e.ConcOut >= 100 {
+			return 0, 0, errInfeasible
+		}
+		return e.FlowOut, e.ConcOut, nil
+	}
+
+	if m.share != nil {
+		for i := range out {
+			if _, _, err := liquor(i, m.share[i], m.in.FeedConc, m.tf); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		flow, conc, tin := m.in.FeedFlow, m.in.FeedConc, m.tf
+		for _, no := range m.order {
+			var err error
+			if flow, conc, err = liquor(no-1, flow, conc, tin); err != nil {
+				return nil, err
+			}
+			tin = out[no-1].TempOut
+		}
+	}
+
+	heating := m.ts
+	for i := range out {
+		e := &out[i]
+		e.No, e.Name, e.UA = i+1, EffectName(i+1), m.ua[i]
+		e.HeatingTemp = heating
+		e.BPE = m.pkg.BoilingPointRise(e.ConcOut, e.TempOut)
+		e.VaporTemp = e.TempOut - e.BPE
+		e.Duty = e.UA * (e.HeatingTemp - e.TempOut)
+		heating = e.VaporTemp
+	}
+	return out, nil
+}
+
+// t/h → kg/s
+const tphToKgs = 1000.0 / 3600
+
+// 蒸发量 t/h、热负荷 kW 低于 −negativeTolerance 视为负值（高于此值的为收敛误差）
+const negativeTolerance = 1e-6
+
+func (m *simModel) residual(x []float64) ([]float64, error) {
+	effects, err := m.state(x)
+	if err != nil {
+		return nil, err
+	}
+	n := len(effects)
+	res := make([]float64, 0, 2*n+1)
+	for i, e := range effects {
+		cond := x[0]
+		if i > 0 {
+			cond = x[i]
+		}
+		res = append(res, e.Duty-cond*tphToKgs*steam.LatentHeat(e.HeatingTemp))
+
+		cp := m.pkg.HeatCapacity(e.ConcIn, e.InletTemp)
+		sensible := e.FlowIn * tphToKgs * cp * (e.InletTemp - e.TempOut)
+		res = append(res, e.Duty+sensible-e.Vapor*tphToKgs*steam.LatentHeat(e.VaporTemp))
+	}
+	last := effects[n-1]
+	res = append(res, last.UA*(last.VaporTemp-m.in.CondenserTemp))
+	return res, nil
+}
+
+// 初值：忽略沸点升高和显热，各效热负荷相等，温差与 UA 成反比；蒸发量按进料封顶以保证浓度可行
+func (m *simModel) initial() []float64 {
+	n := len(m.ua)
+	x := make([]float64, 2*n+1)
+	var sumInv float64
+	for _, ua := range m.ua {
+		sumInv += 1 / ua
+	}
+	q := max(m.ts-m.in.CondenserTemp, 1) / sumInv
+	var total float64
+	t := m.ts
+	for i, ua := range m.ua {
+		t -= q / ua
+		x[1+n+i] = t
+		x[1+i] = q / steam.LatentHeat(t) / tphToKgs
+		total += x[1+i]
+	}
+	x[0] = q / steam.LatentHeat(m.ts) / tphToKgs
+
+	// 出料浓度不超过 (进料浓度+100)/2
+	frac := 1 - m.in.FeedConc/((m.in.FeedConc+100)/2)
+	switch {
+	case m.share != nil:
+		for i, f := range m.share {
+			x[1+i] = min(x[1+i], frac*f)
+		}
+	case total > frac*m.in.FeedFlow:
+		for i := 1; i <= n; i++ {
+			x[i] *= frac * m.in.FeedFlow / total
+		}
+	}
+	return x
+}
+
+// Simulate 求解多效蒸发稳态热量、质量衡算，预测各效出料温度、浓度和完成液流量
+func Simulate(in SimInput) (SimResult, error) {
+	pkg, ok := property.Get(in.Property)
+	if in.FeedArrangement == "" {
+		in.FeedArrangement = FeedForward
+	}
+	data := SimResult{
+		Property:        in.Property,
+		TargetConc:      in.TargetConc,
+		FeedFlow:        in.FeedFlow,
+		FeedConc:        in.FeedConc,
+		FeedTemp:        in.FeedTemp,
+		SteamPressure:   in.SteamPressure,
+		SteamTemp:       in.SteamTemp,
+		CondenserTemp:   in.CondenserTemp,
+		FeedArrangement: in.FeedArrangement,
+	}
+	if data.FeedTemp == 0 {
+		data.FeedTemp = DefaultFeedTemp
+	}
+	if !ok {
+		return data, fmt.Errorf("未知物性包: %s", in.Property)
+	}
+	data.Property, data.PropertyLabel = pkg.Name(), pkg.Label()
+	if data.TargetConc == 0 {
+		data.TargetConc = pkg.DefaultTargetConc()
+	}
+	if err := in.Validate(); err != nil {
+		return data, err
+	}
+	if in.SteamPressure > 0 {
+		data.SteamTemp = steam.SaturationTemperature(in.SteamPressure)
+	}
+	if data.SteamTemp <= in.CondenserTemp {
+		return data, fmt.Errorf("生蒸汽温度 %.1f℃ 应高于冷凝器温度 %.1f℃", data.SteamTemp, in.CondenserTemp)
+	}
+	order, err := flowOrder(in.FeedArrangement, in.FeedOrder, len(in.Effects))
+	if err != nil {
+		return data, err
+	}
+	data.FlowOrder = order
+
+	m := &simModel{pkg: pkg, in: in, order: order, ts: data.SteamTemp, tf: data.FeedTemp}
+	for _, e := range in.Effects {
+		h := e.Health
+		if h == 0 {
+			h = 1
+		}
+		m.ua = append(m.ua, e.Qnom/e.DtDesign*h)
+	}
+	if in.FeedArrangement == FeedParallel {
+		var total float64
+		for _, e := range in.Effects {
+			total += shareOrOne(e.FeedShare)
+		}
+		for _, e := range in.Effects {
+			m.share = append(m.share, in.FeedFlow*shareOrOne(e.FeedShare)/total)
+		}
+	}
+
+	x, iter, err := newton(m.residual, m.initial())
+	data.Iterations = iter
+	if err != nil {
+		return data, fmt.Errorf("稳态求解失败：%v（可检查生蒸汽与冷凝器温度、进料流量是否匹配）", err)
+	}
+	effects, _ := m.state(x)
+	// 蒸发量或热负荷为负的根不是物理解：该效加热量不足以将进料加热至沸腾，不存在全部效沸腾的稳态
+	for _, e := range effects {
+		if e.Duty < -negativeTolerance || e.Vapor < -negativeTolerance {
+			return data, fmt.Errorf("稳态不可行：%s热负荷或蒸发量为负（%.2f kW，%.2f t/h），加热量不足以将进料加热至沸腾，应减少进料量或预热进料",
+				e.Name, e.Duty, e.Vapor)
+		}
+	}
+	data.Effects = effects
+	data.SteamFlow = x[0]
+
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		data.TotalEvaporation += e.Vapor
+		e.DensOut = pkg.Density(e.TempOut, e.ConcOut)
+		if pkg.Conc(e.TempOut, e.DensOut).Domain != property.InRange {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("%s：出料浓度 %.2f%% 超出密度表范围，密度为外推值", e.Name, e.ConcOut))
+		}
+		e.Solubility = pkg.SolubilityLimit(e.TempOut)
+		if e.Solubility > 0 && e.ConcOut >= e.Solubility*(1-DefaultCrystalMargin) {
+			e.CrystalRisk = true
+			data.Warnings = append(data.Warnings, fmt.Sprintf("%s：出料浓度 %.2f%% 接近或超过饱和浓度 %.2f%%，有结晶风险", e.Name, e.ConcOut, e.Solubility))
+		}
+	}
+	data.ProductFlow = in.FeedFlow - data.TotalEvaporation
+	data.ProductConc = in.FeedFlow * in.FeedConc / data.ProductFlow
+	if data.SteamFlow > 0 {
+		data.SteamEconomy = data.TotalEvaporation / data.SteamFlow
+	}
+	if data.ProductConc < data.TargetConc {
+		data.Warnings = append(data.Warnings, fmt.Sprintf("完成液浓度 %.2f%% 低于目标浓度 %.2f%%", data.ProductConc, data.TargetConc))
+	}
+	return data, nil
+}
+
+func shareOrOne(s float64) float64 {
+	if s == 0 {
+		return 1
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package evaluator
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func testSimInput(arr FeedArrangement) SimInput {
+	return SimInput{
+		FeedFlow:        20,
+		FeedConc:        18,
+		FeedTemp:        70,
+		SteamTemp:       116,
+		CondenserTemp:   60,
+		FeedArrangement: arr,
+		Effects: []SimEffectInput{
+			{Qnom: 1200, DtDesign: 25, Health: 1},
+			{Qnom: 1000, DtDesign: 22, Health: 1},
+			{Qnom: 800, DtDesign: 18, Health: 1},
+		},
+	}
+}
+
+func TestSimulateMassClosure(t *testing.T) {
+	for _, arr := range []FeedArrangement{FeedForward, FeedBackward, FeedParallel} {
+		in := testSimInput(arr)
+		res, err := Simulate(in)
+		if err != nil {
+			t.Fatalf("%s: %v", arr, err)
+		}
+		// 溶质守恒：进料溶质 = 完成液溶质
+		feed, product := in.FeedFlow*in.FeedConc, res.ProductFlow*res.ProductConc
+		if math.Abs(feed-product) > 1e-6*feed {
+			t.Errorf("%s: FeedFlow·FeedConc = %.6f, ProductFlow·ProductConc = %.6f", arr, feed, product)
+		}
+		// 总质量守恒：进料 = 完成液 + 总蒸发量，且各效蒸发量非负
+		if d := in.FeedFlow - res.ProductFlow - res.TotalEvaporation; math.Abs(d) > 1e-9 {
+			t.Errorf("%s: 质量不平衡 %g t/h", arr, d)
+		}
+		for _, e := range res.Effects {
+			if e.Vapor < 0 || e.Duty < 0 {
+				t.Errorf("%s: %s 蒸发量 %.3f t/h，热负荷 %.1f kW", arr, e.Name, e.Vapor, e.Duty)
+			}
+		}
+	}
+}
+
+func TestSimulateInfeasible(t *testing.T) {
+	// 冷进料过多时末效无法沸腾，不应返回蒸发量为负的解
+	in := testSimInput(FeedParallel)
+	in.FeedFlow, in.FeedTemp = 55, 0
+	_, err := Simulate(in)
+	if err == nil || !strings.Contains(err.Error(), "稳态不可行") {
+		t.Errorf("Simulate 应返回稳态不可行错误，got %v", err)
+	}
+}
+
+func TestNewton(t *testing.T) {
+	// x² + y² = 4, x − y = 0 → x = y = √2
+	f := func(x []float64) ([]float64, error) {
+		return []float64{x[0]*x[0] + x[1]*x[1] - 4, x[0] - x[1]}, nil
+	}
+	x, _, err := newton(f, []float64{1, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range x {
+		if math.Abs(v-math.Sqrt2) > 1e-6 {
+			t.Errorf("newton = %v, want [√2 √2]", x)
+		}
+	}
+}
+
+func TestSolveLinearSingular(t *testing.T) {
+	a := [][]float64{{1, 2}, {2, 4}}
+	if _, err := solveLinear(a, []float64{1, 2}); err == nil {
+		t.Error("奇异矩阵应返回错误")
+	}
+}
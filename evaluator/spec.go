@@ -0,0 +1,66 @@
+package evaluator
+
+import "fmt"
+
+// 默认投料推荐系数（相对理论最大投料量）
+const (
+	RecommendLowFactor  = 0.75 // 推荐下限
+	RecommendHighFactor = 0.95 // 推荐上限
+	SuggestFactor       = 0.90 // 建议设定值（最优经济点）
+)
+
+// DefaultSpecName 默认产品规格名称
+const DefaultSpecName = "默认"
+
+// ProductSpec 产品规格：目标浓度、推荐投料区间和经济运行点
+type ProductSpec struct {
+	Name          string  // 规格名称
+	Property      string  // 适用物性包，为空时不限
+	TargetConc    float64 // 目标浓度 %，为0时使用物性包默认目标浓度
+	LowFactor     float64 // 推荐下限系数
+	HighFactor    float64 // 推荐上限系数
+	SuggestFactor float64 // 建议设定值系数（最优经济点）
+}
+
+// DefaultSpec 默认产品规格：目标浓度取物性包默认值，推荐系数 0.75/0.95/0.90
+func DefaultSpec() ProductSpec {
+	return ProductSpec{
+		Name:          DefaultSpecName,
+		LowFactor:     RecommendLowFactor,
+		HighFactor:    RecommendHighFactor,
+		SuggestFactor: SuggestFactor,
+	}
+}
+
+// 未填写的项取默认值
+func (s ProductSpec) withDefaults() ProductSpec {
+	d := DefaultSpec()
+	if s.Name == "" {
+		s.Name = d.Name
+	}
+	if s.LowFactor == 0 {
+		s.LowFactor = d.LowFactor
+	}
+	if s.HighFactor == 0 {
+		s.HighFactor = d.HighFactor
+	}
+	if s.SuggestFactor == 0 {
+		s.SuggestFactor = d.SuggestFactor
+	}
+	return s
+}
+
+// MaxRecommendFactor 推荐系数上限：推荐投料量不超过理论最大投料量
+const MaxRecommendFactor = 1.0
+
+// Validate 检查规格是否合理：目标浓度不为负，0 < 下限 ≤ 建议值 ≤ 上限 ≤ MaxRecommendFactor
+func (s ProductSpec) Validate() error {
+	s = s.withDefaults()
+	if s.TargetConc < 0 || s.TargetConc >= 100 {
+		return fmt.Errorf("产品规格 %s 目标浓度应在0～100%%之间", s.Name)
+	}
+	if s.LowFactor <= 0 || s.LowFactor > s.SuggestFactor || s.SuggestFactor > s.HighFactor || s.HighFactor > MaxRecommendFactor {
+		return fmt.Errorf("产品规格 %s 推荐系数应满足 0 < 下限 ≤ 建议值 ≤ 上限 ≤ %g", s.Name, MaxRecommendFactor)
+	}
+	return nil
+}
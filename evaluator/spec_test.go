@@ -0,0 +1,23 @@
+package evaluator
+
+import "testing"
+
+func TestProductSpecValidate(t *testing.T) {
+	for _, c := range []struct {
+		name string
+		spec ProductSpec
+		ok   bool
+	}{
+		{"默认", DefaultSpec(), true},
+		{"系数为0取默认值", ProductSpec{Name: "a", TargetConc: 50}, true},
+		{"上限为1", ProductSpec{Name: "b", LowFactor: 0.8, SuggestFactor: 0.9, HighFactor: 1}, true},
+		{"下限为负", ProductSpec{Name: "c", LowFactor: -0.1}, false},
+		{"上限超过1", ProductSpec{Name: "d", HighFactor: 5}, false},
+		{"建议值高于上限", ProductSpec{Name: "e", SuggestFactor: 0.97, HighFactor: 0.95}, false},
+		{"目标浓度超过100%", ProductSpec{Name: "f", TargetConc: 100}, false},
+	} {
+		if err := c.spec.Validate(); (err == nil) != c.ok {
+			t.Errorf("%s: Validate() = %v", c.name, err)
+		}
+	}
+}
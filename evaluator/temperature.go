@@ -0,0 +1,55 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"test/property"
+	"test/steam"
+)
+
+// 计划温差允许超出有效温差的量 ℃（测量误差）
+const DtTolerance = 0.5
+
+// 计算各效沸点升高和有效传热温差，检查计划温差是否可以实现。
+// 有效温差 = 加热蒸汽温度 − 溶液沸点，溶液沸点 = 汽室压力下的饱和温度 + 沸点升高。
+// 加热蒸汽温度取输入值，未提供时取上一效汽室压力对应的饱和温度（上一效二次蒸汽作为本效加热蒸汽），
+// I效取生蒸汽饱和温度。
+func checkTemperatures(pkg property.Package, data *PlantResult) {
+	prevVapor := data.SteamTemp // 上一效由汽室压力得到的二次蒸汽温度，0表示未知
+	for i := range data.Effects {
+		e := &data.Effects[i]
+
+		if e.VaporPressure > 0 {
+			e.VaporTemp = steam.SaturationTemperature(e.VaporPressure)
+			e.BPE = pkg.BoilingPointRise(e.ConcOut, e.VaporTemp)
+			e.BoilTemp = e.VaporTemp + e.BPE
+		} else {
+			e.BPE = pkg.BoilingPointRise(e.ConcOut, e.TempOut)
+			e.BoilTemp = e.TempOut
+			e.VaporTemp = e.TempOut - e.BPE
+		}
+
+		switch {
+		case e.SteamTemp > 0:
+			e.HeatingTemp = e.SteamTemp
+		case prevVapor > 0:
+			e.HeatingTemp = prevVapor
+		}
+		if e.VaporPressure > 0 {
+			prevVapor = e.VaporTemp
+		} else {
+			prevVapor = 0
+		}
+
+		if e.HeatingTemp == 0 {
+			continue
+		}
+		e.EffectiveDt = e.HeatingTemp - e.BoilTemp
+		e.DtChecked = true
+		e.DtAchievable = e.DtSet <= e.EffectiveDt+DtTolerance
+		if !e.DtAchievable {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("%s：计划温差 %.1f℃ 超过有效温差 %.1f℃（加热蒸汽 %.1f℃ − 溶液沸点 %.1f℃，沸点升高 %.2f℃），无法实现",
+				e.Name, e.DtSet, e.EffectiveDt, e.HeatingTemp, e.BoilTemp, e.BPE))
+		}
+	}
+}
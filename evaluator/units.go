@@ -0,0 +1,142 @@
+package evaluator
+
+import (
+	"fmt"
+
+	"test/property"
+)
+
+// FlowBasis 进料流量计量基准
+type FlowBasis string
+
+const (
+	FlowMass   FlowBasis = "mass"   // 质量流量 t/h
+	FlowVolume FlowBasis = "volume" // 体积流量 m³/h（如电磁流量计）
+)
+
+// Unit 流量单位
+func (b FlowBasis) Unit() string {
+	if b == FlowVolume {
+		return "m³/h"
+	}
+	return "t/h"
+}
+
+// Label 计量基准显示名称
+func (b FlowBasis) Label() string {
+	if b == FlowVolume {
+		return "体积流量"
+	}
+	return "质量流量"
+}
+
+// 未提供进料温度时按常温计 ℃
+const DefaultFeedTemp = 20.0
+
+// 进料温度，未提供时取默认值
+func (data *PlantResult) feedTemp() float64 {
+	if data.FeedTemp > 0 {
+		return data.FeedTemp
+	}
+	return DefaultFeedTemp
+}
+
+// FeedConcSource 进料浓度来源
+type FeedConcSource string
+
+const (
+	FeedSourceManual   FeedConcSource = "manual"   // 手动输入
+	FeedSourceMeasured FeedConcSource = "measured" // 由进料温度和密度识别
+)
+
+// Label 来源显示名称
+func (s FeedConcSource) Label() string {
+	if s == FeedSourceMeasured {
+		return "由进料温度、密度自动识别"
+	}
+	return "手动输入"
+}
+
+// 确定进料浓度并换算为水合物质量分数%，质量平衡始终以水合物质量分数计算。
+// 提供了进料温度和密度时自动识别，否则使用手动输入值；
+// g/L 换算需要进料密度，未提供时按进料温度由密度表迭代估算。
+func convertFeed(pkg property.Package, data *PlantResult) error {
+	if _, err := property.ParseUnit(string(data.FeedConcUnit)); err != nil {
+		return err
+	}
+	if _, err := property.ParseUnit(string(data.DisplayUnit)); err != nil {
+		return err
+	}
+
+	f := pkg.Formula()
+
+	// 同时提供进料温度和密度且未选择手动输入时，按出料浓度相同的插值方法识别进料浓度
+	if !data.FeedConcManual && data.FeedTemp > 0 && data.FeedDensityInput > 0 {
+		conc := pkg.Conc(data.FeedTemp, data.FeedDensityInput)
+		if conc.Domain != property.Invalid {
+			data.FeedConc = conc.Conc
+			data.FeedConcSource = FeedSourceMeasured
+			data.FeedConcInput = f.FromHydrate(data.FeedConcUnit, conc.Conc, data.FeedDensityInput)
+			if w := conc.Domain.Warning(); w != "" {
+				data.Warnings = append(data.Warnings, "进料："+w)
+			}
+			return nil
+		}
+		data.Warnings = append(data.Warnings, "进料："+conc.Domain.Warning()+"，改用手动输入的进料浓度")
+	}
+	data.FeedConcSource = FeedSourceManual
+
+	switch {
+	case data.FeedConcUnit != property.UnitMetalGL:
+		data.FeedConc = f.ToHydrate(data.FeedConcUnit, data.FeedConcInput, 0)
+	case data.FeedDensity > 0:
+		data.FeedConc = f.ToHydrate(property.UnitMetalGL, data.FeedConcInput, data.FeedDensity)
+	default:
+		data.FeedConc, data.FeedDensity = property.HydrateFromMetalGL(pkg, data.FeedConcInput, data.feedTemp())
+	}
+	if data.FeedConc >= 100 {
+		return fmt.Errorf("进料浓度 %g %s 换算后超过100%%", data.FeedConcInput, f.Label(data.FeedConcUnit))
+	}
+	if data.FeedDensity == 0 {
+		data.FeedDensity = pkg.Density(data.feedTemp(), data.FeedConc)
+	}
+	return nil
+}
+
+// 按显示单位换算进料、目标和各效出料浓度，g/L 使用各自的密度
+func convertDisplay(pkg property.Package, data *PlantResult) {
+	f := pkg.Formula()
+	u := data.DisplayUnit
+	data.DisplayLabel = f.Label(u)
+	data.FeedConcDisplay = f.FromHydrate(u, data.FeedConc, data.FeedDensity)
+	for i := range data.Effects {
+		e := &data.Effects[i]
+		e.ConcOutDisplay = f.FromHydrate(u, e.ConcOut, e.DensOut)
+	}
+
+	// 目标浓度按出完成液一效的出料温度估算密度
+	targetTemp := data.feedTemp()
+	if len(data.Effects) > 0 {
+		targetTemp = data.productEffect().TempOut
+	}
+	data.TargetConcDisplay = f.FromHydrate(u, data.TargetConc, pkg.Density(targetTemp, data.TargetConc))
+}
+
+// 体积流量按进料密度（t/m³ 与 g/cm³ 数值相同）换算为质量流量，质量平衡使用质量流量
+func convertFlow(data *PlantResult) error {
+	switch data.FlowBasis {
+	case FlowMass:
+		data.ActualFlow = data.FlowInput
+	case FlowVolume:
+		if data.FeedDensity <= 0 {
+			return fmt.Errorf("体积流量换算需要进料密度")
+		}
+		data.ActualFlow = data.FlowInput * data.FeedDensity
+		if data.FeedTemp == 0 && data.FeedDensityInput == 0 {
+			data.Warnings = append(data.Warnings, fmt.Sprintf("未提供进料温度和密度，体积流量按%.0f℃估算的进料密度 %.3f g/cm³ 换算", DefaultFeedTemp, data.FeedDensity))
+		}
+	default:
+		return fmt.Errorf("未知流量计量基准: %s", data.FlowBasis)
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"test/evaluator"
+	"test/history"
+)
+
+// 保存一次成功的评估
+func saveEvaluation(operator string, in evaluator.PlantInput, result evaluator.PlantResult) (history.Record, error) {
+	return store.Add(history.Record{
+		Time:     time.Now(),
+		Operator: operator,
+		Input:    in,
+		Result:   result,
+	})
+}
+
+// 最近 days 天记录的结垢趋势
+func recentTrends(days int) []history.EffectTrend {
+	now := time.Now()
+	return history.Trend(store.List(now.AddDate(0, 0, -days), time.Time{}), now)
+}
+
+// 按字符串编号查找历史记录
+func lookupRecord(id string) (history.Record, bool) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return history.Record{}, false
+	}
+	return store.Get(n)
+}
+
+// 解析日期范围 from/to（YYYY-MM-DD，含当天），未填写表示不限
+func parseDateRange(r *http.Request) (from, to time.Time, err error) {
+	if s := r.FormValue("from"); s != "" {
+		if from, err = time.ParseInLocation("2006-01-02", s, time.Local); err != nil {
+			return
+		}
+	}
+	if s := r.FormValue("to"); s != "" {
+		if to, err = time.ParseInLocation("2006-01-02", s, time.Local); err != nil {
+			return
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	return
+}
+
+type HistoryPageData struct {
+	From    string
+	To      string
+	Error   string
+	Records []history.Record
+}
+
+// 历史记录页面：按日期筛选，点击编号在表单中重新打开
+func historyHandler(w http.ResponseWriter, r *http.Request) {
+	data := HistoryPageData{From: r.FormValue("from"), To: r.FormValue("to")}
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		data.Error = "日期格式应为 YYYY-MM-DD"
+	} else {
+		data.Records = store.List(from, to)
+	}
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>评估历史记录</title>
+    <style>
+        body{font-family:Arial;margin:20px;background:#f8f8f8;}
+        .header{background:#4CAF50;color:white;padding:15px;text-align:center;margin-bottom:20px;}
+        .summary{background:white;padding:15px;border-radius:8px;margin-bottom:20px;box-shadow: 0 2px 4px rgba(0,0,0,0.1);}
+        .ok{background:#d4edda !important;} 
+        .warn{background:#fff3cd !important;} 
+        .bad{background:#f8d7da !important;}
+        table{width:100%;border-collapse:collapse;margin:10px 0;}
+        td,th{border:1px solid #ccc;padding:6px;text-align:center;font-size:14px;}
+        .error{background:#f8d7da;color:#721c24;padding:10px;border-radius:4px;margin-bottom:20px;}
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>评估历史记录</h1>
+        <p><a href="/" style="color:white;">返回评估页面</a></p>
+    </div>
+
+    {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+
+    <div class="summary">
+        <form method="GET">
+            起始日期 <input type="date" name="from" value="{{.From}}">
+            截止日期 <input type="date" name="to" value="{{.To}}">
+            <input type="submit" value="筛选">
+        </form>
+        <table>
+            <tr><th>编号</th><th>时间</th><th>操作员</th><th>产品规格</th><th>进料浓度 %</th><th>实际流量 t/h</th><th>ΣQ_set t/h</th><th>各效 出料浓度 / Q_run / 健康度 / 状态</th></tr>
+            {{range .Records}}
+            <tr>
+                <td><a href="/?id={{.ID}}">#{{.ID}}</a></td>
+                <td>{{.Time.Format "2006-01-02 15:04:05"}}</td>
+                <td>{{.Operator}}</td>
+                <td>{{.Result.Spec.Name}}</td>
+                <td>{{printf "%.2f" .Result.FeedConc}}</td>
+                <td>{{printf "%.1f" .Result.ActualFlow}}</td>
+                <td>{{printf "%.1f" .Result.TotalQset}}</td>
+                <td>
+                    {{range .Result.Effects}}
+                    <div {{if gt .Health 0.9}}class="ok"{{else if gt .Health 0.7}}class="warn"{{else}}class="bad"{{end}}>
+                        {{.Name}}：{{printf "%.2f" .ConcOut}}% / {{printf "%.2f" .Qrun}} t/h / {{printf "%.2f" .Health}} / {{.Status}}{{if .UActual}} / Rf {{printf "%.2e" .Rf}} m²·K/W{{end}}
+                    </div>
+                    {{end}}
+                </td>
+            </tr>
+            {{else}}
+            <tr><td colspan="8">暂无记录</td></tr>
+            {{end}}
+        </table>
+    </div>
+</body>
+</html>
+	`
+	tmplParsed := template.Must(template.New("history").Parse(tmpl))
+	tmplParsed.Execute(w, data)
+}
+
+// GET /api/v1/history?from=YYYY-MM-DD&to=YYYY-MM-DD：按日期范围列出记录，最新的在前
+func apiHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	from, to, err := parseDateRange(r)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, "日期格式应为 YYYY-MM-DD")
+		return
+	}
+	writeJSON(w, http.StatusOK, store.List(from, to))
+}
+
+// GET /api/v1/history/{id}：单条记录
+func apiHistoryRecordHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	rec, ok := lookupRecord(r.PathValue("id"))
+	if !ok {
+		writeJSONError(w, http.StatusNotFound, "历史记录不存在")
+		return
+	}
+	writeJSON(w, http.StatusOK, rec)
+}
+
+// GET /api/v1/trend?days=30：最近若干天的各效结垢趋势
+func apiTrendHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	days := trendDays
+	if s := r.FormValue("days"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n <= 0 {
+			writeJSONError(w, http.StatusBadRequest, "days 必须为正整数")
+			return
+		}
+		days = n
+	}
+	writeJSON(w, http.StatusOK, recentTrends(days))
+}
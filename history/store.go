@@ -0,0 +1,126 @@
+// Package history 评估记录的本地存储：按行追加写入JSON文件，无需外部数据库。
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"test/evaluator"
+)
+
+// Record 一次评估记录
+type Record struct {
+	ID       int64                 // 记录编号，自增
+	Time     time.Time             // 评估时间
+	Operator string                // 操作员
+	Input    evaluator.PlantInput  // 输入参数
+	Result   evaluator.PlantResult // 计算结果（ConcOut/Qrun/Health/Status等）
+}
+
+// Store 评估记录存储，启动时把文件全部读入内存，新记录追加到文件末尾
+type Store struct {
+	mu      sync.RWMutex
+	path    string
+	records []Record // 按写入顺序（即时间顺序）排列
+	nextID  int64
+}
+
+// Open 打开（不存在时创建）记录文件
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, nextID: 1}
+
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	for line := 1; sc.Scan(); line++ {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(sc.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("%s 第%d行: %w", path, line, err)
+		}
+		s.records = append(s.records, rec)
+		if rec.ID >= s.nextID {
+			s.nextID = rec.ID + 1
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Add 保存一条记录，编号由存储分配，返回保存后的记录
+func (s *Store) Add(rec Record) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec.ID = s.nextID
+	if rec.Time.IsZero() {
+		rec.Time = time.Now()
+	}
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return Record{}, err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return Record{}, err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return Record{}, err
+	}
+	if err := f.Close(); err != nil {
+		return Record{}, err
+	}
+
+	s.records = append(s.records, rec)
+	s.nextID++
+	return rec, nil
+}
+
+// Get 按编号查找记录
+func (s *Store) Get(id int64) (Record, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, rec := range s.records {
+		if rec.ID == id {
+			return rec, true
+		}
+	}
+	return Record{}, false
+}
+
+// List 返回时间在 [from, to) 内的记录，最新的在前；零值表示不限
+func (s *Store) List(from, to time.Time) []Record {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := []Record{}
+	for i := len(s.records) - 1; i >= 0; i-- {
+		rec := s.records[i]
+		if !from.IsZero() && rec.Time.Before(from) {
+			continue
+		}
+		if !to.IsZero() && !rec.Time.Before(to) {
+			continue
+		}
+		out = append(out, rec)
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+package history
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"test/evaluator"
+)
+
+// MinTrendSpan 拟合所需的最短时间跨度（天）；同一天内的多次评估（含试算）按日平均后只算一个点
+const MinTrendSpan = 1.0
+
+// EffectTrend 单效结垢趋势：对每日平均健康度随时间做最小二乘直线拟合
+type EffectTrend struct {
+	No             int       // 序号（从1开始）
+	Name           string    // 名称，如 "III效"
+	Points         int       // 参与拟合的天数（每天的记录取平均）
+	Rate           float64   // 结垢速率：健康度每天下降量，>0 表示在恶化
+	Current        float64   // 拟合得到的当前健康度
+	DaysToModerate float64   // 预计多少天后进入中度结垢，0 表示已进入，<0 表示无法预测
+	DaysToSevere   float64   // 预计多少天后进入严重结垢，0 表示已进入，<0 表示无法预测
+	ModerateDate   time.Time // 预计进入中度结垢的日期
+	SevereDate     time.Time // 预计进入严重结垢的日期
+	Message        string    // 页面提示，如 "III效 预计 12 天后进入中度结垢"
+}
+
+// Trend 根据记录（任意顺序）拟合各效结垢趋势，now 为预测起点。
+// 只使用与最新记录配置相同（物性包、效数、进料流程和料液流向）的记录，各效按序号对应；
+// 每效先按日求平均健康度再拟合，健康度未知的效不参与。
+func Trend(records []Record, now time.Time) []EffectTrend {
+	if len(records) == 0 {
+		return nil
+	}
+	latest := records[0]
+	for _, rec := range records {
+		if rec.Time.After(latest.Time) {
+			latest = rec
+		}
+	}
+
+	trends := make([]EffectTrend, len(latest.Result.Effects))
+	for i := range trends {
+		var keys []string
+		days := map[string][]float64{}   // 日期 → 记录时间（相对 now 的天数）
+		health := map[string][]float64{} // 日期 → 健康度
+		for _, rec := range records {
+			if !sameConfig(rec.Result, latest.Result) || rec.Result.Effects[i].Unknown {
+				continue
+			}
+			k := rec.Time.In(now.Location()).Format("2006-01-02")
+			if _, ok := days[k]; !ok {
+				keys = append(keys, k)
+			}
+			days[k] = append(days[k], rec.Time.Sub(now).Hours()/24)
+			health[k] = append(health[k], rec.Result.Effects[i].Health)
+		}
+		var x, y []float64
+		for _, k := range keys {
+			x = append(x, mean(days[k]))
+			y = append(y, mean(health[k]))
+		}
+		trends[i] = fitTrend(i+1, x, y, now)
+	}
+	return trends
+}
+
+// 两条记录的装置配置是否相同，配置不同时各效健康度不可比
+func sameConfig(a, b evaluator.PlantResult) bool {
+	return a.Property == b.Property && len(a.Effects) == len(b.Effects) &&
+		arrangement(a) == arrangement(b) && slices.Equal(flowOrder(a), flowOrder(b))
+}
+
+// 早期记录没有进料流程字段，按顺流计
+func arrangement(r evaluator.PlantResult) evaluator.FeedArrangement {
+	if r.FeedArrangement == "" {
+		return evaluator.FeedForward
+	}
+	return r.FeedArrangement
+}
+
+func flowOrder(r evaluator.PlantResult) []int {
+	if len(r.FlowOrder) > 0 {
+		return r.FlowOrder
+	}
+	order := make([]int, len(r.Effects))
+	for i := range order {
+		order[i] = i + 1
+	}
+	return order
+}
+
+func mean(v []float64) float64 {
+	var s float64
+	for _, x := range v {
+		s += x
+	}
+	return s / float64(len(v))
+}
+
+// 以 now 为时间原点拟合 health = a + b·days
+func fitTrend(no int, days, health []float64, now time.Time) EffectTrend {
+	t := EffectTrend{
+		No:             no,
+		Name:           evaluator.EffectName(no),
+		Points:         len(days),
+		DaysToModerate: -1,
+		DaysToSevere:   -1,
+	}
+
+	var sx, sy, sxx, sxy float64
+	for k := range days {
+		sx += days[k]
+		sy += health[k]
+		sxx += days[k] * days[k]
+		sxy += days[k] * health[k]
+	}
+	m := float64(len(days))
+	den := m*sxx - sx*sx
+	if len(days) < 2 || den <= 1e-12 || slices.Max(days)-slices.Min(days) < MinTrendSpan {
+		t.Message = fmt.Sprintf("%s 记录不足（需跨 %g 天以上的多日记录），无法拟合趋势", t.Name, MinTrendSpan)
+		return t
+	}
+	b := (m*sxy - sx*sy) / den
+	a := (sy - b*sx) / m
+	t.Rate = -b
+	t.Current = a
+
+	t.DaysToModerate, t.ModerateDate = daysUntil(a, b, evaluator.ModerateFoulingHealth, now)
+	t.DaysToSevere, t.SevereDate = daysUntil(a, b, evaluator.SevereFoulingHealth, now)
+
+	switch {
+	case t.DaysToSevere == 0:
+		t.Message = t.Name + " 已进入严重结垢"
+	case t.DaysToModerate == 0 && t.DaysToSevere > 0:
+		t.Message = fmt.Sprintf("%s 已进入中度结垢，%s进入严重结垢", t.Name, when(t.DaysToSevere))
+	case t.DaysToModerate == 0:
+		t.Message = t.Name + " 已进入中度结垢"
+	case t.DaysToModerate > 0:
+		t.Message = fmt.Sprintf("%s %s进入中度结垢", t.Name, when(t.DaysToModerate))
+	default:
+		t.Message = t.Name + " 健康度无下降趋势"
+	}
+	return t
+}
+
+// 预计天数的提示文字，不足1天时为“即将”
+func when(days float64) string {
+	if days < 1 {
+		return "即将（1天内）"
+	}
+	return fmt.Sprintf("预计 %.0f 天后", days)
+}
+
+// 拟合直线降到阈值所需天数；当前已不高于阈值返回0，不会降到阈值返回-1
+func daysUntil(a, b, threshold float64, now time.Time) (float64, time.Time) {
+	if a <= threshold {
+		return 0, now
+	}
+	if b >= 0 {
+		return -1, time.Time{}
+	}
+	d := (threshold - a) / b
+	return d, now.Add(time.Duration(d * 24 * float64(time.Hour)))
+}
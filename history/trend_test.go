@@ -0,0 +1,97 @@
+package history
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"test/evaluator"
+)
+
+var testNow = time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+func TestDaysUntil(t *testing.T) {
+	for _, c := range []struct {
+		name    string
+		a, b    float64
+		want    float64
+		wantDay time.Time
+	}{
+		{"已低于阈值", 0.6, -0.01, 0, testNow},
+		{"等于阈值", 0.7, -0.01, 0, testNow},
+		{"无下降趋势", 0.9, 0, -1, time.Time{}},
+		{"健康度上升", 0.9, 0.01, -1, time.Time{}},
+		{"20天后", 0.9, -0.01, 20, testNow.AddDate(0, 0, 20)},
+	} {
+		d, day := daysUntil(c.a, c.b, evaluator.ModerateFoulingHealth, testNow)
+		if math.Abs(d-c.want) > 1e-9 || !day.Equal(c.wantDay) {
+			t.Errorf("%s: daysUntil = %g %v, want %g %v", c.name, d, day, c.want, c.wantDay)
+		}
+	}
+}
+
+func TestFitTrend(t *testing.T) {
+	// 健康度 0.9 − 0.01·days，now 时 0.9，20 天后 0.7，40 天后 0.5
+	days := []float64{-10, -5, 0}
+	health := []float64{1.0, 0.95, 0.9}
+	tr := fitTrend(3, days, health, testNow)
+	if math.Abs(tr.Rate-0.01) > 1e-9 || math.Abs(tr.Current-0.9) > 1e-9 {
+		t.Errorf("Rate = %g, Current = %g, want 0.01, 0.9", tr.Rate, tr.Current)
+	}
+	if math.Abs(tr.DaysToModerate-20) > 1e-9 || math.Abs(tr.DaysToSevere-40) > 1e-9 {
+		t.Errorf("DaysToModerate = %g, DaysToSevere = %g, want 20, 40", tr.DaysToModerate, tr.DaysToSevere)
+	}
+	if tr.Message != "III效 预计 20 天后进入中度结垢" {
+		t.Errorf("Message = %q", tr.Message)
+	}
+}
+
+func TestFitTrendShortSpan(t *testing.T) {
+	// 几秒钟内的两次评估不足以拟合
+	sec := 1.0 / 86400
+	tr := fitTrend(1, []float64{-5 * sec, 0}, []float64{1.0, 0.8}, testNow)
+	if tr.Rate != 0 || tr.DaysToModerate != -1 || !strings.Contains(tr.Message, "记录不足") {
+		t.Errorf("短时间跨度应无法拟合，got %+v", tr)
+	}
+}
+
+func TestFitTrendImminent(t *testing.T) {
+	tr := fitTrend(1, []float64{-2, -1, 0}, []float64{0.9, 0.8, 0.705}, testNow)
+	if tr.DaysToModerate <= 0 || tr.DaysToModerate >= 1 || !strings.Contains(tr.Message, "即将") {
+		t.Errorf("1天内进入中度结垢应提示即将，got %g %q", tr.DaysToModerate, tr.Message)
+	}
+}
+
+func record(at time.Time, order []int, health ...float64) Record {
+	r := Record{Time: at}
+	r.Result.Property = "CoSO4"
+	r.Result.FeedArrangement = evaluator.FeedForward
+	r.Result.FlowOrder = order
+	for i, h := range health {
+		r.Result.Effects = append(r.Result.Effects, evaluator.EffectData{No: i + 1, Health: h})
+	}
+	return r
+}
+
+func TestTrendDailyAverageAndConfig(t *testing.T) {
+	day := 24 * time.Hour
+	records := []Record{
+		record(testNow.Add(-2*day), []int{1, 2}, 1.0, 1.0),
+		// 同一天的试算取平均：(0.9 + 1.1)/2 = 1.0
+		record(testNow.Add(-day), []int{1, 2}, 0.9, 0.9),
+		record(testNow.Add(-day+time.Minute), []int{1, 2}, 1.1, 1.1),
+		record(testNow, []int{1, 2}, 1.0, 1.0),
+		// 配置不同（三效）的记录不参与
+		record(testNow.Add(-3*day), []int{1, 2, 3}, 0.1, 0.1, 0.1),
+	}
+	trends := Trend(records, testNow)
+	if len(trends) != 2 {
+		t.Fatalf("len(trends) = %d, want 2", len(trends))
+	}
+	for _, tr := range trends {
+		if tr.Points != 3 || math.Abs(tr.Rate) > 1e-9 {
+			t.Errorf("%s: Points = %d, Rate = %g, want 3, 0", tr.Name, tr.Points, tr.Rate)
+		}
+	}
+}
@@ -1,480 +1,621 @@
-// go build -ldflags="-s -w" -o 硫酸钴溶液三效蒸发加热室健康度评估系统.exe main.go
-package main
-
-import (
-	"fmt"
-	"html/template"
-	"net/http"
-	"sort"
-	"strconv"
-	"time"
-)
-
-// === 密度表 (温度℃ → []{七水质量分数%, 密度g/cm³}) ===
-var densityTable = map[float64][][2]float64{
-	20:  {{0, 1.000}, {10, 1.092}, {15, 1.142}, {20, 1.195}, {25, 1.250}, {30, 1.308}, {35, 1.368}, {40, 1.431}, {45, 1.497}, {48, 1.540}, {50, 1.569}, {51, 1.584}, {52, 1.599}},
-	40:  {{0, 1.000}, {15, 1.126}, {20, 1.175}, {25, 1.227}, {30, 1.282}, {35, 1.340}, {40, 1.401}, {45, 1.465}, {48, 1.505}, {50, 1.533}, {51, 1.547}, {52, 1.561}},
-	50:  {{0, 1.000}, {20, 1.160}, {25, 1.210}, {30, 1.263}, {35, 1.319}, {40, 1.378}, {45, 1.440}, {48, 1.478}, {50, 1.505}, {51, 1.519}, {52, 1.533}},
-	55:  {{0, 1.000}, {30, 1.247}, {34, 1.293}, {38, 1.345}, {42, 1.400}, {46, 1.458}, {49, 1.500}, {50, 1.515}, {51, 1.530}, {51.8, 1.540}},
-	60:  {{0, 1.000}, {32, 1.268}, {36, 1.316}, {40, 1.368}, {44, 1.423}, {48, 1.482}, {50, 1.512}, {51, 1.527}, {52, 1.542}, {53, 1.557}},
-	80:  {{0, 0.992}, {40, 1.315}, {45, 1.367}, {48, 1.405}, {50, 1.433}, {51, 1.447}, {52, 1.461}},
-	100: {{0, 0.980}, {45, 1.330}, {48, 1.365}, {50, 1.392}, {51, 1.405}, {52, 1.418}},
-}
-
-// 双向线性插值：温度 + 密度 → 七水合硫酸钴质量分数%
-func getConc(temp, density float64) float64 {
-	keys := make([]float64, 0, len(densityTable))
-	for k := range densityTable {
-		keys = append(keys, k)
-	}
-	sort.Float64s(keys)
-
-	var t1, t2 float64
-	for _, t := range keys {
-		if t <= temp {
-			t1 = t
-		}
-		if t >= temp && t2 == 0 {
-			t2 = t
-			break
-		}
-	}
-	if t2 == 0 {
-		t2 = t1
-	}
-
-	// 在给定温度下的密度-浓度表中插值
-	interp := func(tbl [][2]float64, d float64) float64 {
-		for i := 0; i < len(tbl)-1; i++ {
-			if d >= tbl[i][1] && d <= tbl[i+1][1] {
-				a := (d - tbl[i][1]) / (tbl[i+1][1] - tbl[i][1])
-				return tbl[i][0] + a*(tbl[i+1][0]-tbl[i][0])
-			}
-		}
-		// 如果密度超出范围，返回边界值
-		if d < tbl[0][1] {
-			return tbl[0][0]
-		}
-		return tbl[len(tbl)-1][0]
-	}
-
-	c1 := interp(densityTable[t1], density)
-	if t1 == t2 {
-		return c1
-	}
-	c2 := interp(densityTable[t2], density)
-	return c1 + (c2-c1)/(t2-t1)*(temp-t1)
-}
-
-type EffectData struct {
-	Qnom1     float64 // I效厂家预设换热能力 kW
-	Qnom2     float64 // II效厂家预设换热能力 kW
-	Qnom3     float64 // III效厂家预设换热能力 kW
-	DtDesign1 float64 // I效预设温差 ℃
-	DtDesign2 float64 // II效预设温差 ℃
-	DtDesign3 float64 // III效预设温差 ℃
-	Qset1     float64 // I效理论蒸发能力 t/h
-	Qset2     float64 // II效理论蒸发能力 t/h
-	Qset3     float64 // III效理论蒸发能力 t/h
-	DtSet1    float64 // I效计划温差 ℃
-	DtSet2    float64 // II效计划温差 ℃
-	DtSet3    float64 // III效计划温差 ℃
-	TempOut1  float64 // I效出料温度 ℃
-	TempOut2  float64 // II效出料温度 ℃
-	TempOut3  float64 // III效出料温度 ℃
-	DensOut1  float64 // I效出料密度 g/cm³
-	DensOut2  float64 // II效出料密度 g/cm³
-	DensOut3  float64 // III效出料密度 g/cm³
-	ConcOut1  float64 // I效自动识别浓度 %
-	ConcOut2  float64 // II效自动识别浓度 %
-	ConcOut3  float64 // III效自动识别浓度 %
-	Qrun1     float64 // I效实际蒸发能力 t/h
-	Qrun2     float64 // II效实际蒸发能力 t/h
-	Qrun3     float64 // III效实际蒸发能力 t/h
-	Health1   float64 // I效健康度 Qrun1/Qset1
-	Health2   float64 // II效健康度 Qrun2/Qset2
-	Health3   float64 // III效健康度 Qrun3/Qset3
-	Status1   string  // I效状态
-	Status2   string  // II效状态
-	Status3   string  // III效状态
-}
-
-type PageData struct {
-	Time           string
-	FeedConc       float64    // 手动输入的进料浓度
-	TargetConc     float64    // 目标浓度（52.5%）
-	TotalQset      float64    // 系统峰值脱水能力
-	TheoreticalMax float64    // 理论最大投料量
-	RecommendLow   float64    // 推荐下限
-	RecommendHigh  float64    // 推荐上限
-	SuggestFlow    float64    // 建议设定值
-	ActualFlow     float64    // 用户实际输入流量
-	EffectData     EffectData // 三效数据
-}
-
-// 计算水的汽化潜热（kJ/kg）
-const LatentHeatOfVaporization = 2257.0 // kJ/kg
-
-// 热负荷(kW) → 蒸发能力(t/h) 的转换
-func heatLoadToEvaporation(q_kW float64) float64 {
-	return q_kW * 3600.0 / (LatentHeatOfVaporization * 1000.0)
-}
-
-func main() {
-	http.HandleFunc("/", indexHandler)
-	fmt.Println("服务器启动 → http://localhost:8080")
-	http.ListenAndServe(":8080", nil)
-}
-
-func indexHandler(w http.ResponseWriter, r *http.Request) {
-	data := PageData{
-		Time:       time.Now().Format("2006-01-02 15:04:05"),
-		TargetConc: 52.5, // 固定目标浓度
-		FeedConc:   18.0, // 默认手动输入进料浓度
-		ActualFlow: 55.0, // 默认实际流量
-		EffectData: EffectData{
-			Qnom1: 1200, Qnom2: 1000, Qnom3: 800,
-			DtDesign1: 25, DtDesign2: 22, DtDesign3: 18,
-			DtSet1: 24, DtSet2: 20, DtSet3: 16,
-			TempOut1: 92, TempOut2: 78, TempOut3: 62,
-			DensOut1: 1.190, DensOut2: 1.290, DensOut3: 1.550, // 调整后的预设密度
-		},
-	}
-
-	if r.Method == "POST" {
-		// 读取手动输入的进料浓度
-		feedConc, err := strconv.ParseFloat(r.FormValue("feed_conc"), 64)
-		if err == nil && feedConc > 0 {
-			data.FeedConc = feedConc
-		}
-
-		// 读取实际流量
-		actualFlow, err := strconv.ParseFloat(r.FormValue("actual_flow"), 64)
-		if err == nil && actualFlow > 0 {
-			data.ActualFlow = actualFlow
-		}
-
-		// 读取I效参数
-		if qnom1, err := strconv.ParseFloat(r.FormValue("qnom_1"), 64); err == nil && qnom1 > 0 {
-			data.EffectData.Qnom1 = qnom1
-		}
-		if dtDesign1, err := strconv.ParseFloat(r.FormValue("dt_design_1"), 64); err == nil && dtDesign1 > 0 {
-			data.EffectData.DtDesign1 = dtDesign1
-		}
-		if dtSet1, err := strconv.ParseFloat(r.FormValue("dt_set_1"), 64); err == nil && dtSet1 > 0 {
-			data.EffectData.DtSet1 = dtSet1
-		}
-		if tempOut1, err := strconv.ParseFloat(r.FormValue("temp_1"), 64); err == nil && tempOut1 > 0 {
-			data.EffectData.TempOut1 = tempOut1
-		}
-		if densOut1, err := strconv.ParseFloat(r.FormValue("dens_1"), 64); err == nil && densOut1 > 0 {
-			data.EffectData.DensOut1 = densOut1
-		}
-
-		// 读取II效参数
-		if qnom2, err := strconv.ParseFloat(r.FormValue("qnom_2"), 64); err == nil && qnom2 > 0 {
-			data.EffectData.Qnom2 = qnom2
-		}
-		if dtDesign2, err := strconv.ParseFloat(r.FormValue("dt_design_2"), 64); err == nil && dtDesign2 > 0 {
-			data.EffectData.DtDesign2 = dtDesign2
-		}
-		if dtSet2, err := strconv.ParseFloat(r.FormValue("dt_set_2"), 64); err == nil && dtSet2 > 0 {
-			data.EffectData.DtSet2 = dtSet2
-		}
-		if tempOut2, err := strconv.ParseFloat(r.FormValue("temp_2"), 64); err == nil && tempOut2 > 0 {
-			data.EffectData.TempOut2 = tempOut2
-		}
-		if densOut2, err := strconv.ParseFloat(r.FormValue("dens_2"), 64); err == nil && densOut2 > 0 {
-			data.EffectData.DensOut2 = densOut2
-		}
-
-		// 读取III效参数
-		if qnom3, err := strconv.ParseFloat(r.FormValue("qnom_3"), 64); err == nil && qnom3 > 0 {
-			data.EffectData.Qnom3 = qnom3
-		}
-		if dtDesign3, err := strconv.ParseFloat(r.FormValue("dt_design_3"), 64); err == nil && dtDesign3 > 0 {
-			data.EffectData.DtDesign3 = dtDesign3
-		}
-		if dtSet3, err := strconv.ParseFloat(r.FormValue("dt_set_3"), 64); err == nil && dtSet3 > 0 {
-			data.EffectData.DtSet3 = dtSet3
-		}
-		if tempOut3, err := strconv.ParseFloat(r.FormValue("temp_3"), 64); err == nil && tempOut3 > 0 {
-			data.EffectData.TempOut3 = tempOut3
-		}
-		if densOut3, err := strconv.ParseFloat(r.FormValue("dens_3"), 64); err == nil && densOut3 > 0 {
-			data.EffectData.DensOut3 = densOut3
-		}
-	}
-
-	// 第一部分：计算各效理论蒸发能力
-	qSet1 := heatLoadToEvaporation(data.EffectData.Qnom1) * (data.EffectData.DtSet1 / data.EffectData.DtDesign1)
-	qSet2 := heatLoadToEvaporation(data.EffectData.Qnom2) * (data.EffectData.DtSet2 / data.EffectData.DtDesign2)
-	qSet3 := heatLoadToEvaporation(data.EffectData.Qnom3) * (data.EffectData.DtSet3 / data.EffectData.DtDesign3)
-
-	data.TotalQset = qSet1 + qSet2 + qSet3
-	data.EffectData.Qset1 = qSet1
-	data.EffectData.Qset2 = qSet2
-	data.EffectData.Qset3 = qSet3
-
-	// 计算理论最大投料量和推荐范围
-	if data.TargetConc > data.FeedConc {
-		concentrationRatio := data.TargetConc / (data.TargetConc - data.FeedConc)
-		data.TheoreticalMax = data.TotalQset * concentrationRatio
-		data.RecommendLow = data.TheoreticalMax * 0.75
-		data.RecommendHigh = data.TheoreticalMax * 0.95
-		data.SuggestFlow = data.TheoreticalMax * 0.90
-	} else {
-		data.TheoreticalMax = 0
-		data.RecommendLow = 0
-		data.RecommendHigh = 0
-		data.SuggestFlow = 0
-	}
-
-	// 第二部分：自动识别各效出料浓度（通过双向插值）
-	// 密度单位统一为g/cm³，直接使用
-	data.EffectData.ConcOut1 = getConc(data.EffectData.TempOut1, data.EffectData.DensOut1)
-	data.EffectData.ConcOut2 = getConc(data.EffectData.TempOut2, data.EffectData.DensOut2)
-	data.EffectData.ConcOut3 = getConc(data.EffectData.TempOut3, data.EffectData.DensOut3)
-
-	// 使用用户实际输入的流量计算实际蒸发量
-	actualFlow := data.ActualFlow
-
-	// I效实际蒸发量（进料浓度为手动输入的FeedConc）
-	if data.EffectData.ConcOut1 > data.FeedConc && data.EffectData.ConcOut1 > 0 {
-		data.EffectData.Qrun1 = actualFlow * (data.EffectData.ConcOut1 - data.FeedConc) / data.EffectData.ConcOut1
-	} else {
-		data.EffectData.Qrun1 = 0
-	}
-
-	// II效实际蒸发量（进料浓度为I效自动识别的出料浓度）
-	cin2 := data.EffectData.ConcOut1
-	if data.EffectData.ConcOut2 > cin2 && data.EffectData.ConcOut2 > 0 {
-		data.EffectData.Qrun2 = (actualFlow - data.EffectData.Qrun1) * (data.EffectData.ConcOut2 - cin2) / data.EffectData.ConcOut2
-	} else {
-		data.EffectData.Qrun2 = 0
-	}
-
-	// III效实际蒸发量（进料浓度为II效自动识别的出料浓度）
-	cin3 := data.EffectData.ConcOut2
-	if data.EffectData.ConcOut3 > cin3 && data.EffectData.ConcOut3 > 0 {
-		data.EffectData.Qrun3 = (actualFlow - data.EffectData.Qrun1 - data.EffectData.Qrun2) * (data.EffectData.ConcOut3 - cin3) / data.EffectData.ConcOut3
-	} else {
-		data.EffectData.Qrun3 = 0
-	}
-
-	// 计算健康度
-	if data.EffectData.Qset1 > 0 {
-		data.EffectData.Health1 = data.EffectData.Qrun1 / data.EffectData.Qset1
-	} else {
-		data.EffectData.Health1 = 0
-	}
-	if data.EffectData.Qset2 > 0 {
-		data.EffectData.Health2 = data.EffectData.Qrun2 / data.EffectData.Qset2
-	} else {
-		data.EffectData.Health2 = 0
-	}
-	if data.EffectData.Qset3 > 0 {
-		data.EffectData.Health3 = data.EffectData.Qrun3 / data.EffectData.Qset3
-	} else {
-		data.EffectData.Health3 = 0
-	}
-
-	// 状态判断
-	switch {
-	case data.EffectData.Health1 > 1.1:
-		data.EffectData.Status1 = "超负荷运行"
-	case data.EffectData.Health1 > 0.9:
-		data.EffectData.Status1 = "运行良好"
-	case data.EffectData.Health1 > 0.7:
-		data.EffectData.Status1 = "轻微结垢"
-	case data.EffectData.Health1 > 0.5:
-		data.EffectData.Status1 = "中度结垢"
-	default:
-		data.EffectData.Status1 = "严重结垢"
-	}
-
-	switch {
-	case data.EffectData.Health2 > 1.1:
-		data.EffectData.Status2 = "超负荷运行"
-	case data.EffectData.Health2 > 0.9:
-		data.EffectData.Status2 = "运行良好"
-	case data.EffectData.Health2 > 0.7:
-		data.EffectData.Status2 = "轻微结垢"
-	case data.EffectData.Health2 > 0.5:
-		data.EffectData.Status2 = "中度结垢"
-	default:
-		data.EffectData.Status2 = "严重结垢"
-	}
-
-	switch {
-	case data.EffectData.Health3 > 1.1:
-		data.EffectData.Status3 = "超负荷运行"
-	case data.EffectData.Health3 > 0.9:
-		data.EffectData.Status3 = "运行良好"
-	case data.EffectData.Health3 > 0.7:
-		data.EffectData.Status3 = "轻微结垢"
-	case data.EffectData.Health3 > 0.5:
-		data.EffectData.Status3 = "中度结垢"
-	default:
-		data.EffectData.Status3 = "严重结垢"
-	}
-
-	// 渲染页面
-	tmpl := `
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="utf-8">
-    <title>三效蒸发健康度评估</title>
-    <style>
-        body{font-family:Arial;margin:20px;background:#f8f8f8;}
-        .header{background:#4CAF50;color:white;padding:15px;text-align:center;margin-bottom:20px;}
-        .summary{background:white;padding:15px;border-radius:8px;margin-bottom:20px;box-shadow: 0 2px 4px rgba(0,0,0,0.1);}
-        .row{display:flex;justify-content:space-between;margin-bottom:20px;}
-        .col{flex:1;margin:0 10px;border:2px solid #ddd;border-radius:8px;padding:15px;background:white;min-width: 300px;}
-        .col h3{text-align:center;margin-top:0;background:#e8f4fd;padding:10px;border-radius:5px;}
-        input[type=number]{width:80px;padding:5px;margin:2px;border:1px solid #ccc;border-radius:3px;}
-        .ok{background:#d4edda !important;} 
-        .warn{background:#fff3cd !important;} 
-        .bad{background:#f8d7da !important;}
-        .critical{background:#f5c6cb !important; color: #721c24; font-weight: bold;}
-        table{width:100%;border-collapse:collapse;margin:10px 0;}
-        td,th{border:1px solid #ccc;padding:6px;text-align:center;font-size:14px;}
-        .param-row{display:flex;justify-content:space-between;margin:5px 0;}
-        .param-item{flex:1;text-align:center;}
-        .status-badge{padding:3px 8px;border-radius:12px;font-size:12px;font-weight:bold;}
-        .info{background:#d1ecf1;color:#0c5460;padding:8px;border-radius:4px;margin:5px 0;font-size:13px;}
-        .highlight{background:#fff3cd;font-weight:bold;}
-    </style>
-</head>
-<body>
-    <div class="header">
-        <h1>三效蒸发加热室健康度评估系统</h1>
-        <p>目标浓度：{{printf "%.2f" .TargetConc}}% | 汽化潜热：2257 kJ/kg</p>
-    </div>
-
-    <form method="POST">
-        <div class="summary">
-            <h3>第一部分：开机投料推荐</h3>
-            <table>
-                <tr>
-                    <td>系统峰值脱水能力 ΣQ_set</td>
-                    <td class="highlight">{{printf "%.1f" .TotalQset}} t/h</td>
-                    <td>手动输入进料浓度</td>
-                    <td><input name="feed_conc" value="{{printf "%.2f" .FeedConc}}" step="0.1"> %</td>
-                </tr>
-                <tr>
-                    <td>目标浓度</td>
-                    <td>{{printf "%.2f" .TargetConc}} %</td>
-                    <td>理论最大投料量</td>
-                    <td>{{printf "%.1f" .TheoreticalMax}} t/h</td>
-                </tr>
-                <tr>
-                    <td>推荐投料范围（安全+高效）</td>
-                    <td class="highlight">{{printf "%.1f" .RecommendLow}} ~ {{printf "%.1f" .RecommendHigh}} t/h</td>
-                    <td>建议设定值</td>
-                    <td class="highlight">{{printf "%.1f" .SuggestFlow}} t/h（90%负荷，最优经济点）</td>
-                </tr>
-                <tr>
-                    <td>用户实际输入流量</td>
-                    <td><input name="actual_flow" value="{{printf "%.1f" .ActualFlow}}" step="0.1"> t/h</td>
-                    <td>当前时间</td>
-                    <td>{{.Time}}</td>
-                </tr>
-            </table>
-        </div>
-
-        <div class="summary">
-            <h3>第二部分：每效健康度评估</h3>
-            <div class="info">
-                <strong>说明：</strong>基于实际运行参数，调整温差Δt_s，ΣQ_set会实时变化，通过实际蒸发量Q_run与理论能力Q_set对比判断加热室健康度
-            </div>
-            
-            <div class="row">
-                <div class="col">
-                    <h3>I效</h3>
-                    <table>
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">设备参数</td></tr>
-                        <tr><td>厂家预设换热能力 Qnom1</td><td><input name="qnom_1" value="{{printf "%.0f" .EffectData.Qnom1}}" step="10"> kW</td></tr>
-                        <tr><td>预设温差 DtDesign1</td><td><input name="dt_design_1" value="{{printf "%.1f" .EffectData.DtDesign1}}" step="0.1"> ℃</td></tr>
-                        
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">运行参数</td></tr>
-                        <tr><td>计划温差 DtSet1</td><td><input name="dt_set_1" value="{{printf "%.1f" .EffectData.DtSet1}}" step="0.1"> ℃</td></tr>
-                        <tr><td>出料温度 TempOut1</td><td><input name="temp_1" value="{{printf "%.1f" .EffectData.TempOut1}}" step="0.1"> ℃</td></tr>
-                        <tr><td>出料密度 DensOut1</td><td><input name="dens_1" value="{{printf "%.3f" .EffectData.DensOut1}}" step="0.001"> g/cm³</td></tr>
-                        
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">计算结果</td></tr>
-                        <tr><td>自动识别浓度 ConcOut1</td><td>{{printf "%.2f" .EffectData.ConcOut1}} %</td></tr>
-                        <tr><td>理论蒸发能力 Qset1</td><td>{{printf "%.2f" .EffectData.Qset1}} t/h</td></tr>
-                        <tr><td>实际蒸发能力 Qrun1</td><td>{{printf "%.2f" .EffectData.Qrun1}} t/h</td></tr>
-                        <tr><td>健康度 Health1</td><td {{if gt .EffectData.Health1 0.9}}class="ok"
-                            {{else if gt .EffectData.Health1 0.7}}class="warn"
-                            {{else}}class="bad"{{end}}>
-                            {{printf "%.2f" .EffectData.Health1}}
-                        </td></tr>
-                        <tr><td>状态</td><td>{{.EffectData.Status1}}</td></tr>
-                    </table>
-                </div>
-                
-                <div class="col">
-                    <h3>II效</h3>
-                    <table>
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">设备参数</td></tr>
-                        <tr><td>厂家预设换热能力 Qnom2</td><td><input name="qnom_2" value="{{printf "%.0f" .EffectData.Qnom2}}" step="10"> kW</td></tr>
-                        <tr><td>预设温差 DtDesign2</td><td><input name="dt_design_2" value="{{printf "%.1f" .EffectData.DtDesign2}}" step="0.1"> ℃</td></tr>
-                        
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">运行参数</td></tr>
-                        <tr><td>计划温差 DtSet2</td><td><input name="dt_set_2" value="{{printf "%.1f" .EffectData.DtSet2}}" step="0.1"> ℃</td></tr>
-                        <tr><td>出料温度 TempOut2</td><td><input name="temp_2" value="{{printf "%.1f" .EffectData.TempOut2}}" step="0.1"> ℃</td></tr>
-                        <tr><td>出料密度 DensOut2</td><td><input name="dens_2" value="{{printf "%.3f" .EffectData.DensOut2}}" step="0.001"> g/cm³</td></tr>
-                        
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">计算结果</td></tr>
-                        <tr><td>自动识别浓度 ConcOut2</td><td>{{printf "%.2f" .EffectData.ConcOut2}} %</td></tr>
-                        <tr><td>理论蒸发能力 Qset2</td><td>{{printf "%.2f" .EffectData.Qset2}} t/h</td></tr>
-                        <tr><td>实际蒸发能力 Qrun2</td><td>{{printf "%.2f" .EffectData.Qrun2}} t/h</td></tr>
-                        <tr><td>健康度 Health2</td><td {{if gt .EffectData.Health2 0.9}}class="ok"
-                            {{else if gt .EffectData.Health2 0.7}}class="warn"
-                            {{else}}class="bad"{{end}}>
-                            {{printf "%.2f" .EffectData.Health2}}
-                        </td></tr>
-                        <tr><td>状态</td><td>{{.EffectData.Status2}}</td></tr>
-                    </table>
-                </div>
-                
-                <div class="col">
-                    <h3>III效</h3>
-                    <table>
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">设备参数</td></tr>
-                        <tr><td>厂家预设换热能力 Qnom3</td><td><input name="qnom_3" value="{{printf "%.0f" .EffectData.Qnom3}}" step="10"> kW</td></tr>
-                        <tr><td>预设温差 DtDesign3</td><td><input name="dt_design_3" value="{{printf "%.1f" .EffectData.DtDesign3}}" step="0.1"> ℃</td></tr>
-                        
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">运行参数</td></tr>
-                        <tr><td>计划温差 DtSet3</td><td><input name="dt_set_3" value="{{printf "%.1f" .EffectData.DtSet3}}" step="0.1"> ℃</td></tr>
-                        <tr><td>出料温度 TempOut3</td><td><input name="temp_3" value="{{printf "%.1f" .EffectData.TempOut3}}" step="0.1"> ℃</td></tr>
-                        <tr><td>出料密度 DensOut3</td><td><input name="dens_3" value="{{printf "%.3f" .EffectData.DensOut3}}" step="0.001"> g/cm³</td></tr>
-                        
-                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">计算结果</td></tr>
-                        <tr><td>自动识别浓度 ConcOut3</td><td>{{printf "%.2f" .EffectData.ConcOut3}} %</td></tr>
-                        <tr><td>理论蒸发能力 Qset3</td><td>{{printf "%.2f" .EffectData.Qset3}} t/h</td></tr>
-                        <tr><td>实际蒸发能力 Qrun3</td><td>{{printf "%.2f" .EffectData.Qrun3}} t/h</td></tr>
-                        <tr><td>健康度 Health3</td><td {{if gt .EffectData.Health3 0.9}}class="ok"
-                            {{else if gt .EffectData.Health3 0.7}}class="warn"
-                            {{else}}class="bad"{{end}}>
-                            {{printf "%.2f" .EffectData.Health3}}
-                        </td></tr>
-                        <tr><td>状态</td><td>{{.EffectData.Status3}}</td></tr>
-                    </table>
-                </div>
-            </div>
-        </div>
-        
-        <div style="text-align:center; padding:20px;">
-            <input type="submit" value="刷新计算" style="padding:10px 30px;font-size:16px;">
-        </div>
-    </form>
-</body>
-</html>
-	`
-	tmplParsed := template.Must(template.New("index").Parse(tmpl))
-	tmplParsed.Execute(w, data)
-}
+// go build -ldflags="-s -w" -o 硫酸钴溶液三效蒸发加热室健康度评估系统.exe .
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"test/evaluator"
+	"test/history"
+	"test/property"
+	"test/scenario"
+)
+
+// 评估历史记录
+var store *history.Store
+
+// 结垢趋势拟合窗口（天）
+var trendDays = 30
+
+// 结晶预警裕量
+var crystalMargin = evaluator.DefaultCrystalMargin
+
+type PageData struct {
+	Time          string
+	Error         string                  `json:",omitempty"` // 输入参数错误，页面回显输入并提示
+	Operator      string                  // 操作员
+	RecordID      int64                   `json:",omitempty"` // 对应的历史记录编号
+	ScenarioSaved string                  `json:"-"`          // 本次保存的情景名称
+	Packages      []propertyOption        `json:"-"`          // 可选物性包
+	Units         []unitOption            `json:"-"`          // 可选浓度单位
+	Specs         []evaluator.ProductSpec `json:"-"`          // 可选产品规格
+	evaluator.PlantResult
+	Trends []history.EffectTrend // 各效结垢趋势
+}
+
+// 页面默认参数
+func defaultInput() evaluator.PlantInput {
+	return evaluator.PlantInput{
+		Property:      property.DefaultName,
+		Spec:          specs[0], // 默认产品规格
+		CrystalMargin: crystalMargin,
+		FeedConc:      18.0, // 默认手动输入进料浓度
+		ActualFlow:    55.0, // 默认实际流量
+		Effects: []evaluator.EffectInput{
+			{Qnom: 1200, DtDesign: 25, DtSet: 24, TempOut: 92, DensOut: 1.190},
+			{Qnom: 1000, DtDesign: 22, DtSet: 20, TempOut: 78, DensOut: 1.290},
+			{Qnom: 800, DtDesign: 18, DtSet: 16, TempOut: 62, DensOut: 1.550}, // 调整后的预设密度
+		},
+	}
+}
+
+// 最大效数，防止异常输入导致分配过大
+const maxEffects = 12
+
+// 各效参数字段名前缀，实际字段名为 前缀+序号，如 qnom_4
+var effectFields = []string{"qnom_", "dt_design_", "dt_set_", "temp_", "dens_", "steam_temp_", "vapor_p_", "area_", "split_"}
+
+// 参数来源：HTML表单或JSON请求体，两者字段名一致
+type paramSource interface {
+	Value(name string) string
+}
+
+// 表单参数
+type formSource struct{ r *http.Request }
+
+func (s formSource) Value(name string) string { return s.r.FormValue(name) }
+
+// 读取大于0的有限数值，未填写或非法（含 Inf、NaN）时保持默认值
+func readFloat(src paramSource, name string, dst *float64) {
+	if v, err := strconv.ParseFloat(src.Value(name), 64); err == nil && v > 0 && !math.IsInf(v, 0) {
+		*dst = v
+	}
+}
+
+// 读取开关量：复选框 on，或 JSON 的 true/1
+func readBool(src paramSource, name string) bool {
+	switch src.Value(name) {
+	case "on", "true", "1":
+		return true
+	}
+	return false
+}
+
+// 在默认参数基础上读取用户输入，产品规格不存在时返回错误
+func parseInput(src paramSource) (evaluator.PlantInput, error) {
+	in := defaultInput()
+
+	// 物性包
+	if name := src.Value("property"); name != "" {
+		in.Property = name
+	}
+
+	// 产品规格，不存在时仍读取其余参数以便页面回显
+	spec, specErr := lookupSpec(src.Value("spec"))
+	if specErr == nil {
+		in.Spec = spec
+	}
+
+	// 读取手动输入的进料浓度和实际流量
+	readFloat(src, "feed_conc", &in.FeedConc)
+	readFloat(src, "actual_flow", &in.ActualFlow)
+	if b := src.Value("flow_basis"); b != "" {
+		in.FlowBasis = evaluator.FlowBasis(b)
+	}
+	if a := src.Value("feed_arrangement"); a != "" {
+		in.FeedArrangement = evaluator.FeedArrangement(a)
+	}
+	in.FeedOrder = parseOrder(src.Value("feed_order"))
+	readFloat(src, "steam_flow", &in.SteamFlow)
+	readFloat(src, "steam_p", &in.SteamPressure)
+	readFloat(src, "cond_temp", &in.CondenserTemp)
+	readFloat(src, "crystal_margin", &in.CrystalMargin)
+	readFloat(src, "feed_temp", &in.FeedTemp)
+	readFloat(src, "feed_dens", &in.FeedDensity)
+	in.FeedConcManual = readBool(src, "feed_conc_manual")
+	if u := src.Value("feed_conc_unit"); u != "" {
+		in.FeedConcUnit = property.Unit(u)
+	}
+	if u := src.Value("display_unit"); u != "" {
+		in.DisplayUnit = property.Unit(u)
+	}
+
+	// 确定效数：优先使用 effects 字段，否则在默认效数基础上顺延识别 qnom_4、temp_5 等字段
+	num := len(in.Effects)
+	var count float64
+	readFloat(src, "effects", &count)
+	if count >= 1 {
+		num = int(min(count, maxEffects))
+	} else {
+		for num < maxEffects && hasEffect(src, num+1) {
+			num++
+		}
+	}
+	effects := make([]evaluator.EffectInput, num)
+	copy(effects, in.Effects)
+	in.Effects = effects
+
+	// 读取各效参数
+	for i := range in.Effects {
+		e := &in.Effects[i]
+		n := strconv.Itoa(i + 1)
+		readFloat(src, "qnom_"+n, &e.Qnom)
+		readFloat(src, "dt_design_"+n, &e.DtDesign)
+		readFloat(src, "dt_set_"+n, &e.DtSet)
+		readFloat(src, "temp_"+n, &e.TempOut)
+		readFloat(src, "dens_"+n, &e.DensOut)
+		readFloat(src, "steam_temp_"+n, &e.SteamTemp)
+		readFloat(src, "vapor_p_"+n, &e.VaporPressure)
+		readFloat(src, "area_"+n, &e.Area)
+		readFloat(src, "split_"+n, &e.FeedShare)
+	}
+	return in, specErr
+}
+
+// 解析效序号列表，如 "2,3,1"；非法项记为0，由评估时校验
+func parseOrder(s string) []int {
+	var out []int
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '，' || r == ' ' }) {
+		n, _ := strconv.Atoi(f)
+		out = append(out, n)
+	}
+	return out
+}
+
+// 是否提供了第n效的任一参数
+func hasEffect(src paramSource, n int) bool {
+	for _, f := range effectFields {
+		if src.Value(f+strconv.Itoa(n)) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// 页面模板公用函数
+var templateFuncs = template.FuncMap{
+	"pct": func(x float64) float64 { return x * 100 }, // 比值 → 百分数
+	"add": func(a, b int) int { return a + b },        // 整数加法，用于序号换算
+	"trend": func(d float64) string { // 差值高亮：增加 up，减少 down
+		switch {
+		case d > scenario.DeltaEpsilon:
+			return "up"
+		case d < -scenario.DeltaEpsilon:
+			return "down"
+		}
+		return ""
+	},
+	"div": func(a, b float64) float64 { // 除法，除数为0时返回0
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	},
+}
+
+func main() {
+	historyPath := flag.String("history", "history.jsonl", "评估历史记录文件")
+	scenarioPath := flag.String("scenarios", "scenarios.json", "命名情景文件")
+	flag.IntVar(&trendDays, "trend-days", trendDays, "结垢趋势拟合使用最近多少天的记录")
+	flag.Float64Var(&crystalMargin, "crystal-margin", crystalMargin, "结晶预警裕量：出料浓度达到饱和浓度的 (1−裕量) 即提示")
+	flag.StringVar(&densityPath, "density", "", "外部密度表文件（CSV：温度,七水质量分数,密度；或JSON），为空时使用内置密度表")
+	flag.StringVar(&specsPath, "specs", "", "产品规格配置文件（JSON），为空时仅使用默认规格")
+	flag.Parse()
+
+	initDensityTable()
+	initSpecs()
+
+	var err error
+	if store, err = history.Open(*historyPath); err != nil {
+		log.Fatalf("打开历史记录失败: %v", err)
+	}
+	if scenarioStore, err = scenario.Open(*scenarioPath); err != nil {
+		log.Fatalf("打开情景文件失败: %v", err)
+	}
+
+	http.HandleFunc("/", indexHandler)
+	http.HandleFunc("/history", historyHandler)
+	http.HandleFunc("/simulate", simulateHandler)
+	http.HandleFunc("/compare", compareHandler)
+	http.HandleFunc("/sweep", sweepHandler)
+	http.HandleFunc("/api/v1/evaluate", apiEvaluateHandler)
+	http.HandleFunc("/api/v1/simulate", apiSimulateHandler)
+	http.HandleFunc("/api/v1/history", apiHistoryHandler)
+	http.HandleFunc("/api/v1/history/{id}", apiHistoryRecordHandler)
+	http.HandleFunc("/api/v1/trend", apiTrendHandler)
+	http.HandleFunc("/api/v1/scenarios", apiScenariosHandler)
+	http.HandleFunc("/api/v1/scenarios/{name}", apiScenarioHandler)
+	http.HandleFunc("/api/v1/compare", apiCompareHandler)
+	http.HandleFunc("/api/v1/sweep", apiSweepHandler)
+	http.HandleFunc("/api/v1/properties", apiPropertiesHandler)
+	http.HandleFunc("/api/v1/specs", apiSpecsHandler)
+	http.HandleFunc("/api/v1/density", apiDensityHandler)
+	http.HandleFunc("/api/v1/density/reload", apiDensityReloadHandler)
+	fmt.Println("服务器启动 → http://localhost:8080")
+	http.ListenAndServe(":8080", nil)
+}
+
+func indexHandler(w http.ResponseWriter, r *http.Request) {
+	in := defaultInput()
+	var operator, loadErr string
+	var recordID int64
+	var parseErr error
+	switch {
+	case r.Method == "POST":
+		in, parseErr = parseInput(formSource{r})
+		operator = r.FormValue("operator")
+	case r.FormValue("id") != "":
+		// 从历史记录重新打开
+		if rec, ok := lookupRecord(r.FormValue("id")); ok {
+			in, operator, recordID = rec.Input, rec.Operator, rec.ID
+		} else {
+			loadErr = "历史记录不存在: " + r.FormValue("id")
+		}
+	case r.FormValue("scenario") != "":
+		// 打开命名情景
+		if sc, ok := scenarioStore.Get(r.FormValue("scenario")); ok {
+			in, operator = sc.Input, sc.Operator
+		} else {
+			loadErr = "情景不存在: " + r.FormValue("scenario")
+		}
+	}
+
+	result, err := evaluator.Evaluate(in)
+	if parseErr != nil {
+		err = parseErr
+	}
+	data := PageData{
+		Time:        time.Now().Format("2006-01-02 15:04:05"),
+		Operator:    operator,
+		RecordID:    recordID,
+		Packages:    propertyOptions(),
+		Specs:       specs,
+		Units:       unitOptions(result.Property),
+		PlantResult: result,
+		Error:       loadErr,
+	}
+	if err != nil {
+		data.Error = err.Error()
+	} else if r.Method == "POST" {
+		if rec, err := saveEvaluation(operator, in, result); err != nil {
+			data.Error = "保存历史记录失败: " + err.Error()
+		} else {
+			data.RecordID = rec.ID
+		}
+		if r.FormValue("action") == "save_scenario" {
+			if sc, err := saveScenario(r.FormValue("scenario_name"), operator, in); err != nil {
+				data.Error = "保存情景失败: " + err.Error()
+			} else {
+				data.ScenarioSaved = sc.Name
+			}
+		}
+	}
+	data.Trends = recentTrends(trendDays)
+
+	// 渲染页面
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>三效蒸发健康度评估</title>
+    <style>
+        body{font-family:Arial;margin:20px;background:#f8f8f8;}
+        .header{background:#4CAF50;color:white;padding:15px;text-align:center;margin-bottom:20px;}
+        .summary{background:white;padding:15px;border-radius:8px;margin-bottom:20px;box-shadow: 0 2px 4px rgba(0,0,0,0.1);}
+        .row{display:flex;flex-wrap:wrap;justify-content:space-between;margin-bottom:20px;}
+        .col{flex:1;margin:0 10px;border:2px solid #ddd;border-radius:8px;padding:15px;background:white;min-width: 300px;}
+        .col h3{text-align:center;margin-top:0;background:#e8f4fd;padding:10px;border-radius:5px;}
+        input[type=number]{width:80px;padding:5px;margin:2px;border:1px solid #ccc;border-radius:3px;}
+        .ok{background:#d4edda !important;} 
+        .warn{background:#fff3cd !important;} 
+        .bad{background:#f8d7da !important;}
+        .critical{background:#f5c6cb !important; color: #721c24; font-weight: bold;}
+        table{width:100%;border-collapse:collapse;margin:10px 0;}
+        td,th{border:1px solid #ccc;padding:6px;text-align:center;font-size:14px;}
+        .param-row{display:flex;justify-content:space-between;margin:5px 0;}
+        .param-item{flex:1;text-align:center;}
+        .status-badge{padding:3px 8px;border-radius:12px;font-size:12px;font-weight:bold;}
+        .info{background:#d1ecf1;color:#0c5460;padding:8px;border-radius:4px;margin:5px 0;font-size:13px;}
+        .highlight{background:#fff3cd;font-weight:bold;}
+        .error{background:#f8d7da;color:#721c24;padding:10px;border-radius:4px;margin-bottom:20px;}
+        .warning{background:#fff3cd;color:#856404;padding:10px;border-radius:4px;margin-bottom:20px;}
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>三效蒸发加热室健康度评估系统</h1>
+        <p>{{.PropertyLabel}} | 产品规格：{{.Spec.Name}} | 目标浓度：{{printf "%.2f" .TargetConc}}% | 汽化潜热按各效沸腾温度取值 | <a href="/history" style="color:white;">历史记录</a> | <a href="/simulate" style="color:white;">稳态模拟</a> | <a href="/compare" style="color:white;">情景对比</a> | <a href="/sweep" style="color:white;">参数扫描</a></p>
+    </div>
+
+    {{if .Error}}<div class="error">输入参数有误：{{.Error}}</div>{{end}}
+    {{if .CrystalRisks}}<div class="error critical"><strong>⚠ 结晶风险：</strong>{{range .CrystalRisks}}<div>{{.}}</div>{{end}}</div>{{end}}
+    {{if .Warnings}}<div class="warning"><strong>注意：</strong>{{range .Warnings}}<div>{{.}}</div>{{end}}</div>{{end}}
+
+    <form method="POST">
+        <div class="summary">
+            <h3>第一部分：开机投料推荐</h3>
+            <table>
+                <tr>
+                    <td>系统峰值脱水能力 ΣQ_set</td>
+                    <td class="highlight">{{printf "%.1f" .TotalQset}} t/h</td>
+                    <td>手动输入进料浓度</td>
+                    <td>
+                        <input name="feed_conc" value="{{printf "%.2f" .FeedConcInput}}" step="0.1">
+                        <select name="feed_conc_unit">
+                            {{range .Units}}<option value="{{.Value}}" {{if eq .Value $.FeedConcUnit}}selected{{end}}>{{.Label}}</option>{{end}}
+                        </select>
+                        {{if ne .FeedConcUnit "hydrate"}}<br><small>= {{printf "%.2f" .FeedConc}} %（质量平衡基准）</small>{{end}}
+                        <br><label><input type="checkbox" name="feed_conc_manual" {{if .FeedConcManual}}checked{{end}}> 强制手动输入</label>
+                        <small {{if eq .FeedConcSource "measured"}}class="ok"{{end}}>来源：{{.FeedConcSource.Label}}</small>
+                    </td>
+                </tr>
+                <tr>
+                    <td>目标浓度</td>
+                    <td>{{printf "%.2f" .TargetConc}} %</td>
+                    <td>理论最大投料量</td>
+                    <td>{{printf "%.1f" .TheoreticalMax}} t/h</td>
+                </tr>
+                <tr>
+                    <td>推荐投料范围（{{printf "%.0f" (pct .Spec.LowFactor)}}%～{{printf "%.0f" (pct .Spec.HighFactor)}}%负荷）</td>
+                    <td class="highlight">{{printf "%.1f" .RecommendLow}} ~ {{printf "%.1f" .RecommendHigh}} t/h</td>
+                    <td>建议设定值</td>
+                    <td class="highlight">{{printf "%.1f" .SuggestFlow}} t/h（{{printf "%.0f" (pct .Spec.SuggestFactor)}}%负荷，最优经济点）{{if eq .FlowBasis "volume"}}<br>≈ {{printf "%.1f" (div .SuggestFlow .FeedDensity)}} m³/h{{end}}</td>
+                </tr>
+                <tr>
+                    <td>按健康度修正的推荐范围{{if .LimitingEffect}}<br><small>受{{(index .Effects (add .LimitingEffect -1)).Name}}限制，单效能力 {{printf "%.2f" .LimitingCapacity}} t/h</small>{{end}}</td>
+                    <td {{if .AdjustedMax}}class="{{if gt .ActualFlow .AdjustedHigh}}bad{{else}}highlight{{end}}"{{end}}>{{if .AdjustedMax}}{{printf "%.1f" .AdjustedLow}} ~ {{printf "%.1f" .AdjustedHigh}} t/h{{if gt .ActualFlow .AdjustedHigh}}<br><small>实际投料已超过修正上限</small>{{end}}{{else}}-{{end}}</td>
+                    <td>修正后建议设定值</td>
+                    <td {{if .AdjustedMax}}class="highlight"{{end}}>{{if .AdjustedMax}}{{printf "%.1f" .AdjustedSuggest}} t/h（修正能力 {{printf "%.1f" .AdjustedQset}} t/h，最大投料 {{printf "%.1f" .AdjustedMax}} t/h）{{else}}-{{end}}</td>
+                </tr>
+                <tr>
+                    <td>用户实际输入流量</td>
+                    <td>
+                        <input name="actual_flow" value="{{printf "%.1f" .FlowInput}}" step="0.1">
+                        <select name="flow_basis">
+                            <option value="mass" {{if eq .FlowBasis "mass"}}selected{{end}}>t/h（质量流量）</option>
+                            <option value="volume" {{if eq .FlowBasis "volume"}}selected{{end}}>m³/h（体积流量）</option>
+                        </select>
+                        <br><small>计量基准：{{.FlowBasis.Label}}{{if eq .FlowBasis "volume"}}，按进料密度 {{printf "%.3f" .FeedDensity}} g/cm³ 折合 {{printf "%.2f" .ActualFlow}} t/h{{end}}</small>
+                    </td>
+                    <td>当前时间</td>
+                    <td>{{.Time}}</td>
+                </tr>
+                <tr>
+                    <td>进料温度（可选）</td>
+                    <td><input name="feed_temp" value="{{if .FeedTemp}}{{printf "%.1f" .FeedTemp}}{{end}}" step="0.1"> ℃</td>
+                    <td>进料密度（可选）</td>
+                    <td><input name="feed_dens" value="{{if .FeedDensityInput}}{{printf "%.3f" .FeedDensityInput}}{{end}}" step="0.001"> g/cm³{{if and (not .FeedDensityInput) .FeedDensity}}<br><small>估算值 {{printf "%.3f" .FeedDensity}} g/cm³</small>{{end}}</td>
+                </tr>
+                <tr>
+                    <td>结果显示单位</td>
+                    <td>
+                        <select name="display_unit">
+                            {{range .Units}}<option value="{{.Value}}" {{if eq .Value $.DisplayUnit}}selected{{end}}>{{.Label}}</option>{{end}}
+                        </select>
+                    </td>
+                    <td>进料 / 目标浓度（显示单位）</td>
+                    <td>{{printf "%.2f" .FeedConcDisplay}} / {{printf "%.2f" .TargetConcDisplay}} {{.DisplayLabel}}</td>
+                </tr>
+                <tr>
+                    <td>生蒸汽流量（可选）</td>
+                    <td><input name="steam_flow" value="{{if .SteamFlow}}{{printf "%.2f" .SteamFlow}}{{end}}" step="0.01"> t/h</td>
+                    <td>生蒸汽绝对压力（可选）</td>
+                    <td><input name="steam_p" value="{{if .SteamPressure}}{{printf "%.1f" .SteamPressure}}{{end}}" step="0.1"> kPa</td>
+                </tr>
+                <tr>
+                    <td>冷凝器温度（可选）</td>
+                    <td><input name="cond_temp" value="{{if .CondenserTemp}}{{printf "%.1f" .CondenserTemp}}{{end}}" step="0.1"> ℃</td>
+                    <td>进料流程</td>
+                    <td>
+                        <select name="feed_arrangement">
+                            <option value="forward" {{if eq .FeedArrangement "forward"}}selected{{end}}>顺流（I → N）</option>
+                            <option value="backward" {{if eq .FeedArrangement "backward"}}selected{{end}}>逆流（N → I）</option>
+                            <option value="parallel" {{if eq .FeedArrangement "parallel"}}selected{{end}}>平流（各效分别进料）</option>
+                            <option value="mixed" {{if eq .FeedArrangement "mixed"}}selected{{end}}>错流（自定义顺序）</option>
+                        </select>
+                        <br>错流顺序 <input name="feed_order" value="{{if eq .FeedArrangement "mixed"}}{{range $i, $n := .FeedOrder}}{{if $i}},{{end}}{{$n}}{{end}}{{end}}" placeholder="如 2,3,1">
+                    </td>
+                </tr>
+                <tr>
+                    <td>物性包（溶液）</td>
+                    <td>
+                        <select name="property">
+                            {{range .Packages}}<option value="{{.Name}}" {{if eq .Name $.Property}}selected{{end}}>{{.Label}}</option>{{end}}
+                        </select>
+                    </td>
+                    <td>结晶预警裕量</td>
+                    <td><input name="crystal_margin" value="{{printf "%.3f" .CrystalMargin}}" step="0.005"></td>
+                </tr>
+                <tr>
+                    <td>产品规格</td>
+                    <td>
+                        <select name="spec">
+                            {{range .Specs}}<option value="{{.Name}}" {{if eq .Name $.Spec.Name}}selected{{end}}>{{.Name}}</option>{{end}}
+                        </select>
+                    </td>
+                    <td>推荐系数（下限 / 建议 / 上限）</td>
+                    <td>{{printf "%.2f" .Spec.LowFactor}} / {{printf "%.2f" .Spec.SuggestFactor}} / {{printf "%.2f" .Spec.HighFactor}}</td>
+                </tr>
+                <tr>
+                    <td>效数</td>
+                    <td><input name="effects" value="{{len .Effects}}" step="1" min="1"> 效</td>
+                    <td>操作员</td>
+                    <td><input name="operator" value="{{.Operator}}">{{if .RecordID}} 记录 #{{.RecordID}}{{end}}</td>
+                </tr>
+            </table>
+        </div>
+
+        <div class="summary">
+            <h3>第二部分：每效健康度评估</h3>
+            <div class="info">
+                <strong>说明：</strong>基于实际运行参数，调整温差Δt_s，ΣQ_set会实时变化，通过实际蒸发量Q_run与理论能力Q_set对比判断加热室健康度
+            </div>
+            {{if .LimitingEffect}}
+            <div class="info warning">
+                <strong>瓶颈分析：</strong>{{with index .Effects (add .LimitingEffect -1)}}{{.Name}}结垢后单效能力最小（Q_set × 健康度 = {{printf "%.2f" $.LimitingCapacity}} t/h，健康度 {{printf "%.2f" .Health}}）{{end}}，
+                串联能力由清洁时的 {{printf "%.2f" .CleanCapacity}} t/h 降至 {{printf "%.2f" .SeriesCapacity}} t/h，因结垢损失脱水能力 {{printf "%.2f" .LostWater}} t/h{{if .TheoreticalMax}}、投料量 {{printf "%.1f" .LostFeed}} t/h{{end}}。
+                {{if .BestCleaning}}{{with index .Effects (add .BestCleaning -1)}}单独清洗{{.Name}}恢复最多：脱水能力 +{{printf "%.2f" .CleanGainWater}} t/h{{if $.TheoreticalMax}}，投料量 +{{printf "%.1f" .CleanGainFeed}} t/h{{end}}。{{end}}{{else}}多效结垢程度相当，单独清洗任一效均无法恢复，需同时清洗。{{end}}
+            </div>
+            {{end}}
+            
+            <div class="info">
+                <strong>进料流程：</strong>{{.FeedArrangement.Label}}{{if ne .FeedArrangement "parallel"}}，料液流向 {{range $i, $n := .FlowOrder}}{{if $i}} → {{end}}{{(index $.Effects (add $n -1)).Name}}{{end}}{{end}}；蒸汽始终由I效流向末效
+            </div>
+
+            <div class="row">
+                {{range .FlowOrder}}{{with index $.Effects (add . -1)}}
+                <div class="col">
+                    <h3>{{.Name}}</h3>
+                    <table>
+                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">设备参数</td></tr>
+                        <tr><td>厂家预设换热能力 Qnom{{.No}}</td><td><input name="qnom_{{.No}}" value="{{printf "%.0f" .Qnom}}" step="10"> kW</td></tr>
+                        <tr><td>预设温差 DtDesign{{.No}}</td><td><input name="dt_design_{{.No}}" value="{{printf "%.1f" .DtDesign}}" step="0.1"> ℃</td></tr>
+                        <tr><td>换热面积 A{{.No}}（可选）</td><td><input name="area_{{.No}}" value="{{if .Area}}{{printf "%.1f" .Area}}{{end}}" step="0.1"> m²</td></tr>
+                        
+                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">运行参数</td></tr>
+                        <tr><td>计划温差 DtSet{{.No}}</td><td><input name="dt_set_{{.No}}" value="{{printf "%.1f" .DtSet}}" step="0.1"> ℃</td></tr>
+                        <tr><td>出料温度 TempOut{{.No}}</td><td><input name="temp_{{.No}}" value="{{printf "%.1f" .TempOut}}" step="0.1"> ℃</td></tr>
+                        <tr><td>出料密度 DensOut{{.No}}</td><td><input name="dens_{{.No}}" value="{{printf "%.3f" .DensOut}}" step="0.001"> g/cm³</td></tr>
+                        <tr><td>加热蒸汽温度 SteamTemp{{.No}}（可选）</td><td><input name="steam_temp_{{.No}}" value="{{if .SteamTemp}}{{printf "%.1f" .SteamTemp}}{{end}}" step="0.1"> ℃</td></tr>
+                        <tr><td>汽室绝对压力 P{{.No}}（可选）</td><td><input name="vapor_p_{{.No}}" value="{{if .VaporPressure}}{{printf "%.1f" .VaporPressure}}{{end}}" step="0.1"> kPa</td></tr>
+                        {{if eq $.FeedArrangement "parallel"}}<tr><td>原料分配比例 split{{.No}}</td><td><input name="split_{{.No}}" value="{{printf "%.2f" .FeedShare}}" step="0.01"></td></tr>{{end}}
+                        
+                        <tr><td colspan="2" style="background:#f0f8ff;font-weight:bold;">计算结果</td></tr>
+                        <tr><td>进料流量 / 进料浓度</td><td>{{printf "%.2f" .FlowIn}} t/h / {{printf "%.2f" .ConcIn}} %</td></tr>
+                        <tr><td>自动识别浓度 ConcOut{{.No}}</td><td {{if .Warning}}class="warn" title="{{.Warning}}"{{end}}>{{printf "%.2f" .ConcOut}} %{{if ne $.DisplayUnit "hydrate"}}<br>= {{printf "%.2f" .ConcOutDisplay}} {{$.DisplayLabel}}{{end}}{{if .Warning}}<br><small>{{.Warning}}</small>{{end}}</td></tr>
+                        <tr><td>沸点升高 BPE{{.No}}</td><td>{{printf "%.2f" .BPE}} ℃</td></tr>
+                        <tr><td>有效温差 Δt_eff{{.No}}</td><td {{if .DtChecked}}{{if .DtAchievable}}class="ok"{{else}}class="bad"{{end}}{{end}}>{{if .DtChecked}}{{printf "%.1f" .EffectiveDt}} ℃{{if not .DtAchievable}}<br><small>计划温差无法实现</small>{{end}}{{else}}-{{end}}</td></tr>
+                        <tr><td>汽化潜热 r{{.No}}（{{printf "%.1f" .TempOut}}℃）</td><td>{{printf "%.1f" .Latent}} kJ/kg</td></tr>
+                        <tr><td>理论蒸发能力 Qset{{.No}}</td><td>{{printf "%.2f" .Qset}} t/h</td></tr>
+                        <tr><td>实际蒸发能力 Qrun{{.No}}</td><td>{{printf "%.2f" .Qrun}} t/h</td></tr>
+                        <tr><td>闪蒸量 Flash{{.No}}（进料 {{printf "%.1f" .InletTemp}}℃）</td><td>{{if .Flash}}{{printf "%.2f" .Flash}} t/h{{else}}-{{end}}</td></tr>
+                        <tr><td>加热室蒸发量 Qrun{{.No}} − Flash{{.No}}</td><td>{{printf "%.2f" .ChamberQrun}} t/h</td></tr>
+                        <tr><td>未扣除闪蒸的健康度</td><td>{{printf "%.2f" .GrossHealth}}</td></tr>
+                        <tr><td>加热室健康度 Health{{.No}}</td><td {{if gt .Health 0.9}}class="ok"
+                            {{else if gt .Health 0.7}}class="warn"
+                            {{else}}class="bad"{{end}}>
+                            {{printf "%.2f" .Health}}
+                        </td></tr>
+                        <tr><td>状态</td><td>{{.Status}}</td></tr>
+                        <tr><td>清洗后可恢复脱水 / 投料</td><td {{if .Bottleneck}}class="warn"{{end}}>{{if .CleanGainWater}}+{{printf "%.2f" .CleanGainWater}} / +{{printf "%.1f" .CleanGainFeed}} t/h{{else}}-{{end}}{{if .Bottleneck}}<br><small>瓶颈效</small>{{end}}</td></tr>
+                        <tr><td>饱和浓度 / 过饱和度</td><td {{if .CrystalRisk}}class="critical"{{end}}>{{if .Solubility}}{{printf "%.2f" .Solubility}} % / {{printf "%.3f" .Supersaturation}}{{else}}-{{end}}</td></tr>
+                        {{if .Area}}
+                        <tr><td>清洁传热系数 U_clean{{.No}}</td><td>{{printf "%.0f" .UClean}} W/(m²·K)</td></tr>
+                        <tr><td>实际传热系数 U{{.No}}（Δt={{printf "%.1f" .UDt}}℃）</td><td>{{if .UActual}}{{printf "%.0f" .UActual}} W/(m²·K){{else}}-{{end}}</td></tr>
+                        <tr><td>污垢热阻 Rf{{.No}}</td><td>{{if .UActual}}{{printf "%.2e" .Rf}} m²·K/W{{else}}-{{end}}</td></tr>
+                        {{end}}
+                    </table>
+                </div>
+                {{end}}{{end}}
+            </div>
+        </div>
+        
+        <div class="summary">
+            <h3>第三部分：蒸汽经济性</h3>
+            {{if .SteamFlow}}
+            <table>
+                <tr>
+                    <td>总蒸发量 ΣQ_run</td>
+                    <td>{{printf "%.2f" .TotalQrun}} t/h</td>
+                    <td>完成液流量</td>
+                    <td>{{printf "%.2f" .ProductFlow}} t/h</td>
+                </tr>
+                <tr>
+                    <td>生蒸汽温度 / 汽化潜热</td>
+                    <td>{{printf "%.1f" .SteamTemp}} ℃ / {{printf "%.1f" .SteamLatent}} kJ/kg</td>
+                    <td>吨完成液汽耗</td>
+                    <td>{{if .SpecificSteam}}{{printf "%.3f" .SpecificSteam}} t/t{{else}}-{{end}}</td>
+                </tr>
+                <tr>
+                    <td>实际蒸汽经济性 ΣQ_run/D</td>
+                    <td class="highlight">{{printf "%.2f" .SteamEconomy}}</td>
+                    <td>理论蒸汽经济性（{{len .Effects}}效）</td>
+                    <td>{{printf "%.2f" .TheoreticalEconomy}}</td>
+                </tr>
+                <tr>
+                    <td>理论生蒸汽消耗</td>
+                    <td>{{printf "%.2f" .TheoreticalSteam}} t/h</td>
+                    <td>实际/理论经济性</td>
+                    <td {{if ge .EconomyRatio 0.9}}class="ok"{{else if ge .EconomyRatio 0.75}}class="warn"{{else}}class="bad"{{end}}>{{printf "%.0f" (pct .EconomyRatio)}}%</td>
+                </tr>
+            </table>
+            {{else}}
+            <div class="info">输入生蒸汽流量后计算蒸汽经济性</div>
+            {{end}}
+        </div>
+
+        <div class="summary">
+            <h3>第四部分：温差优化分配</h3>
+            {{if .AvailableDt}}
+            <div class="info">
+                <strong>说明：</strong>总有效温差 {{printf "%.1f" .AvailableDt}} ℃ = 生蒸汽 {{printf "%.1f" .AllocSteamTemp}} ℃ − 冷凝器 {{printf "%.1f" .AllocCondTemp}} ℃ − ΣBPE，
+                按各效当前健康度下的单位温差蒸发能力反比分配，使各效负荷均衡，串联整体能力最大
+            </div>
+            <table>
+                <tr><th>效</th><th>计划温差 ℃</th><th>建议温差 ℃</th><th>计划 Q_set（按健康度） t/h</th><th>建议 Q_set（按健康度） t/h</th></tr>
+                {{range .Effects}}
+                <tr>
+                    <td>{{.Name}}</td>
+                    <td>{{printf "%.1f" .DtSet}}</td>
+                    <td class="highlight">{{printf "%.1f" .DtOpt}}</td>
+                    <td {{if .Bottleneck}}class="warn"{{end}}>{{printf "%.2f" .QsetPlan}}</td>
+                    <td>{{printf "%.2f" .QsetOpt}}</td>
+                </tr>
+                {{end}}
+                <tr>
+                    <td>合计</td>
+                    <td {{if gt .TotalDtSet .AvailableDt}}class="bad"{{end}}>{{printf "%.1f" .TotalDtSet}}</td>
+                    <td>{{printf "%.1f" .AvailableDt}}</td>
+                    <td>ΣQ_set {{printf "%.2f" .TotalQsetPlan}}<br><small>串联整体能力 {{printf "%.2f" .PlanCapacity}}</small></td>
+                    <td class="highlight">ΣQ_set {{printf "%.2f" .TotalQsetOpt}}</td>
+                </tr>
+            </table>
+            {{else}}
+            <div class="info">输入生蒸汽压力（或I效加热蒸汽温度）和冷凝器温度（或末效汽室压力）后给出温差分配建议</div>
+            {{end}}
+        </div>
+
+        <div class="summary">
+            <h3>第五部分：结垢趋势预测</h3>
+            <div class="info">
+                <strong>说明：</strong>对最近的历史评估记录（与最新一次评估配置相同）按日平均后拟合各效健康度，需跨1天以上的多日记录；健康度降至0.7进入中度结垢，降至0.5进入严重结垢
+            </div>
+            <table>
+                <tr><th>效</th><th>拟合天数</th><th>结垢速率（健康度/天）</th><th>拟合当前健康度</th><th>预计进入中度结垢</th><th>预计进入严重结垢</th><th>提示</th></tr>
+                {{range .Trends}}
+                <tr>
+                    <td>{{.Name}}</td>
+                    <td>{{.Points}}</td>
+                    <td>{{if ge .Points 2}}{{printf "%.4f" .Rate}}{{else}}-{{end}}</td>
+                    <td>{{if ge .Points 2}}{{printf "%.2f" .Current}}{{else}}-{{end}}</td>
+                    <td>{{if gt .DaysToModerate 0.0}}{{.ModerateDate.Format "2006-01-02"}}{{else if eq .DaysToModerate 0.0}}已进入{{else}}-{{end}}</td>
+                    <td>{{if gt .DaysToSevere 0.0}}{{.SevereDate.Format "2006-01-02"}}{{else if eq .DaysToSevere 0.0}}已进入{{else}}-{{end}}</td>
+                    <td {{if eq .DaysToSevere 0.0}}class="bad"{{else if ge .DaysToModerate 0.0}}class="warn"{{end}}>{{.Message}}</td>
+                </tr>
+                {{else}}
+                <tr><td colspan="7">暂无历史记录</td></tr>
+                {{end}}
+            </table>
+        </div>
+
+        <div style="text-align:center; padding:20px;">
+            <input type="submit" value="刷新计算" style="padding:10px 30px;font-size:16px;">
+            情景名称 <input name="scenario_name" value="{{.ScenarioSaved}}">
+            <button type="submit" name="action" value="save_scenario" style="padding:10px 20px;font-size:16px;">计算并保存为情景</button>
+            {{if .ScenarioSaved}}<span class="ok">已保存情景“{{.ScenarioSaved}}”</span>{{end}}
+        </div>
+    </form>
+</body>
+</html>
+	`
+	tmplParsed := template.Must(template.New("index").Funcs(templateFuncs).Parse(tmpl))
+	tmplParsed.Execute(w, data)
+}
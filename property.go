@@ -0,0 +1,128 @@
+package main
+
+import (
+	"log"
+	"net/http"
+	"sync"
+
+	"test/property"
+)
+
+// 外部硫酸钴密度表文件路径，为空时使用内置密度表
+var densityPath string
+
+// 当前密度表来源，用于接口展示
+var (
+	densityMu     sync.Mutex
+	densitySource = "内置"
+)
+
+// 启动时加载外部密度表，失败时退回内置表
+func initDensityTable() {
+	if densityPath == "" {
+		return
+	}
+	if err := reloadDensityTable(); err != nil {
+		log.Printf("加载密度表失败，使用内置密度表: %v", err)
+	}
+}
+
+// 重新读取外部密度表，校验通过后替换当前表
+func reloadDensityTable() error {
+	t, err := property.LoadDensityTable(densityPath)
+	if err != nil {
+		return err
+	}
+	if err := property.CoSO4.SetDensityTable(t); err != nil {
+		return err
+	}
+	densityMu.Lock()
+	densitySource = densityPath
+	densityMu.Unlock()
+	log.Printf("已加载密度表 %s（%d 个温度）", densityPath, len(t))
+	return nil
+}
+
+type densityInfo struct {
+	Source string                // 来源：文件路径或"内置"
+	Table  property.DensityTable // 温度℃ → []{七水质量分数%, 密度g/cm³}
+}
+
+func currentDensityInfo() densityInfo {
+	densityMu.Lock()
+	defer densityMu.Unlock()
+	return densityInfo{Source: densitySource, Table: property.CoSO4.DensityTable()}
+}
+
+// GET /api/v1/density：当前使用的密度表
+func apiDensityHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	writeJSON(w, http.StatusOK, currentDensityInfo())
+}
+
+// POST /api/v1/density/reload：实验室发布修正后热更新密度表，无需重启；校验失败时保持原表
+func apiDensityReloadHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持POST请求")
+		return
+	}
+	if densityPath == "" {
+		writeJSONError(w, http.StatusBadRequest, "未配置密度表文件（-density）")
+		return
+	}
+	if err := reloadDensityTable(); err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, currentDensityInfo())
+}
+
+// 物性包选项
+type propertyOption struct {
+	Name              string  // 名称
+	Label             string  // 显示名称
+	DefaultTargetConc float64 // 默认目标浓度 %
+}
+
+func propertyOptions() []propertyOption {
+	var out []propertyOption
+	for _, p := range property.List() {
+		out = append(out, propertyOption{Name: p.Name(), Label: p.Label(), DefaultTargetConc: p.DefaultTargetConc()})
+	}
+	return out
+}
+
+// GET /api/v1/properties：可选物性包
+func apiPropertiesHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	writeJSON(w, http.StatusOK, propertyOptions())
+}
+
+// 浓度单位选项
+type unitOption struct {
+	Value property.Unit // 单位
+	Label string        // 显示名称，如 "Co g/L"
+}
+
+// 指定物性包的浓度单位选项，物性包不存在时使用默认物性包
+func unitOptions(name string) []unitOption {
+	p, ok := property.Get(name)
+	if !ok {
+		p, _ = property.Get("")
+	}
+	f := p.Formula()
+	var out []unitOption
+	for _, u := range property.Units {
+		out = append(out, unitOption{Value: u, Label: f.Label(u)})
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package property
+
+import "sync"
+
+// 摩尔质量 g/mol
+const (
+	MolarMassCoSO4     = 154.996 // CoSO4
+	MolarMassCoSO47H2O = 281.103 // CoSO4·7H2O
+	MolarMassWater     = 18.015  // H2O
+	MolarMassCo        = 58.933  // Co
+)
+
+// === 硫酸钴密度表 (温度℃ → []{七水质量分数%, 密度g/cm³}) ===
+var coso4DensityTable = DensityTable{
+	20:  {{0, 1.000}, {10, 1.092}, {15, 1.142}, {20, 1.195}, {25, 1.250}, {30, 1.308}, {35, 1.368}, {40, 1.431}, {45, 1.497}, {48, 1.540}, {50, 1.569}, {51, 1.584}, {52, 1.599}},
+	40:  {{0, 1.000}, {15, 1.126}, {20, 1.175}, {25, 1.227}, {30, 1.282}, {35, 1.340}, {40, 1.401}, {45, 1.465}, {48, 1.505}, {50, 1.533}, {51, 1.547}, {52, 1.561}},
+	50:  {{0, 1.000}, {20, 1.160}, {25, 1.210}, {30, 1.263}, {35, 1.319}, {40, 1.378}, {45, 1.440}, {48, 1.478}, {50, 1.505}, {51, 1.519}, {52, 1.533}},
+	55:  {{0, 1.000}, {30, 1.247}, {34, 1.293}, {38, 1.345}, {42, 1.400}, {46, 1.458}, {49, 1.500}, {50, 1.515}, {51, 1.530}, {51.8, 1.540}},
+	60:  {{0, 1.000}, {32, 1.268}, {36, 1.316}, {40, 1.368}, {44, 1.423}, {48, 1.482}, {50, 1.512}, {51, 1.527}, {52, 1.542}, {53, 1.557}},
+	80:  {{0, 0.992}, {40, 1.315}, {45, 1.367}, {48, 1.405}, {50, 1.433}, {51, 1.447}, {52, 1.461}},
+	100: {{0, 0.980}, {45, 1.330}, {48, 1.365}, {50, 1.392}, {51, 1.405}, {52, 1.418}},
+}
+
+// 硫酸钴溶解度（手册数据 g CoSO4/100g水 换算为七水质量分数%，近似值）
+var (
+	coso4SolubilityTemps = []float64{0, 20, 40, 60, 80, 100}
+	coso4SolubilityConcs = []float64{36.9, 48.2, 59.5, 64.4, 63.4, 50.8}
+)
+
+// 硫酸钴在水中的有效解离系数（考虑离子缔合，小于理想值2）
+const coso4VantHoff = 1.3
+
+// 无水硫酸钴比热容 kJ/(kg·K)（约 98 J/(mol·K)）
+const coso4HeatCapacity = 0.63
+
+// CobaltSulfate 硫酸钴（CoSO4·7H2O）物性包，密度表可在运行中热更新
+type CobaltSulfate struct {
+	mu    sync.RWMutex
+	table DensityTable
+}
+
+// CoSO4 内置硫酸钴物性包
+var CoSO4 = &CobaltSulfate{table: coso4DensityTable}
+
+func init() {
+	Register(CoSO4)
+}
+
+func (c *CobaltSulfate) Name() string  { return "CoSO4" }
+func (c *CobaltSulfate) Label() string { return "硫酸钴 CoSO4·7H2O" }
+
+// DefaultTargetConc 默认目标浓度（七水质量分数%）
+func (c *CobaltSulfate) DefaultTargetConc() float64 { return 52.5 }
+
+// Conc 温度 + 密度 → 七水合硫酸钴质量分数%
+func (c *CobaltSulfate) Conc(temp, density float64) ConcResult {
+	return c.DensityTable().Conc(temp, density)
+}
+
+// Density 温度 + 七水质量分数% → 密度g/cm³
+func (c *CobaltSulfate) Density(temp, conc float64) float64 {
+	return c.DensityTable().Density(temp, conc)
+}
+
+// Formula 硫酸钴浓度以七水合物质量分数为基准
+func (c *CobaltSulfate) Formula() Formula {
+	return Formula{
+		Hydrate:            "CoSO4·7H2O",
+		Anhydrous:          "CoSO4",
+		Metal:              "Co",
+		HydrateMolarMass:   MolarMassCoSO47H2O,
+		AnhydrousMolarMass: MolarMassCoSO4,
+		MetalMolarMass:     MolarMassCo,
+	}
+}
+
+// SolubilityLimit 该温度下的饱和浓度（七水质量分数%）
+func (c *CobaltSulfate) SolubilityLimit(temp float64) float64 {
+	return interpolate(coso4SolubilityTemps, coso4SolubilityConcs, temp)
+}
+
+// BoilingPointRise 按依数性估算沸点升高 ΔT = i·Kb(T)·m，
+// Kb 随二次蒸汽饱和温度变化，i 为考虑离子缔合的有效解离系数
+func (c *CobaltSulfate) BoilingPointRise(conc, temp float64) float64 {
+	if conc <= 0 || conc >= 100 {
+		return 0
+	}
+	// 每100g溶液：七水合物 conc g，其中结晶水随溶质计入溶剂
+	salt := conc / MolarMassCoSO47H2O  // mol
+	water := 100 - salt*MolarMassCoSO4 // g
+	m := salt / (water / 1000)         // mol/kg水
+	return coso4VantHoff * EbullioscopicConstant(temp) * m
+}
+
+// HeatCapacity 按质量加和估算溶液比热容：cp = cp_水·(1−w) + cp_CoSO4·w，w 为无水 CoSO4 质量分数，近似值
+func (c *CobaltSulfate) HeatCapacity(conc, temp float64) float64 {
+	w := min(max(conc, 0), 100) / 100 * MolarMassCoSO4 / MolarMassCoSO47H2O
+	return WaterHeatCapacity*(1-w) + coso4HeatCapacity*w
+}
+
+// DensityTable 当前使用的密度表，调用方不得修改
+func (c *CobaltSulfate) DensityTable() DensityTable {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.table
+}
+
+// DefaultDensityTable 内置密度表
+func (c *CobaltSulfate) DefaultDensityTable() DensityTable {
+	return coso4DensityTable
+}
+
+// SetDensityTable 校验通过后替换当前密度表，校验失败时保持原表不变
+func (c *CobaltSulfate) SetDensityTable(t DensityTable) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	c.table = t
+	c.mu.Unlock()
+	return nil
+}
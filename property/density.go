@@ -0,0 +1,283 @@
+package property
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DensityTable 密度表：温度℃ → []{质量分数%, 密度g/cm³}
+type DensityTable map[float64][][2]float64
+
+// 外部密度表的覆盖范围要求：温度至少覆盖 20～100℃，每个温度的浓度从0%覆盖到50%以上
+const (
+	CoverageTempLow  = 20.0
+	CoverageTempHigh = 100.0
+	CoverageConc     = 50.0
+)
+
+// Temperatures 按升序排列的温度
+func (t DensityTable) Temperatures() []float64 {
+	keys := make([]float64, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	sort.Float64s(keys)
+	return keys
+}
+
+// Validate 检查单调性和覆盖范围：同一温度下浓度、密度均严格递增
+func (t DensityTable) Validate() error {
+	temps := t.Temperatures()
+	if len(temps) < 2 {
+		return fmt.Errorf("密度表至少需要两个温度")
+	}
+	if temps[0] > CoverageTempLow || temps[len(temps)-1] < CoverageTempHigh {
+		return fmt.Errorf("密度表温度范围 %g～%g℃ 未覆盖 %g～%g℃", temps[0], temps[len(temps)-1], CoverageTempLow, CoverageTempHigh)
+	}
+	for _, temp := range temps {
+		row := t[temp]
+		if len(row) < 2 {
+			return fmt.Errorf("%g℃ 至少需要两个数据点", temp)
+		}
+		if row[0][0] != 0 || row[len(row)-1][0] < CoverageConc {
+			return fmt.Errorf("%g℃ 浓度范围 %g～%g%% 未覆盖 0～%g%%", temp, row[0][0], row[len(row)-1][0], CoverageConc)
+		}
+		for i := 1; i < len(row); i++ {
+			if row[i][0] <= row[i-1][0] {
+				return fmt.Errorf("%g℃ 浓度不是严格递增：%g%% 之后为 %g%%", temp, row[i-1][0], row[i][0])
+			}
+			if row[i][1] <= row[i-1][1] {
+				return fmt.Errorf("%g℃ 密度不是严格递增：%g%% 处 %g g/cm³ 不大于 %g g/cm³", temp, row[i][0], row[i][1], row[i-1][1])
+			}
+		}
+	}
+	return nil
+}
+
+// densityPoint 外部文件中的一行：温度℃、质量分数%、密度g/cm³
+type densityPoint struct {
+	Temperature float64 `json:"temperature"`
+	Conc        float64 `json:"conc"`
+	Density     float64 `json:"density"`
+}
+
+// LoadDensityTable 从外部文件读取并校验密度表。
+// 按扩展名识别格式：.json 为 [{"temperature":20,"conc":10,"density":1.092}, ...]，
+// 其余按CSV读取，每行为 温度,质量分数,密度，可带表头。
+func LoadDensityTable(path string) (DensityTable, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var points []densityPoint
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.NewDecoder(f).Decode(&points)
+	} else {
+		points, err = readDensityCSV(f)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	t := DensityTable{}
+	for _, p := range points {
+		t[p.Temperature] = append(t[p.Temperature], [2]float64{p.Conc, p.Density})
+	}
+	for _, row := range t {
+		sort.Slice(row, func(i, j int) bool { return row[i][0] < row[j][0] })
+	}
+	if err := t.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return t, nil
+}
+
+func readDensityCSV(r io.Reader) ([]densityPoint, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 3
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+
+	var points []densityPoint
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return points, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		var v [3]float64
+		var bad error
+		numeric := 0
+		for i, s := range rec {
+			if v[i], err = strconv.ParseFloat(strings.TrimSpace(s), 64); err != nil {
+				bad = err
+			} else {
+				numeric++
+			}
+		}
+		if bad != nil {
+			if first && numeric == 0 {
+				continue // 表头：各列均不是数值
+			}
+			line, _ := cr.FieldPos(0) // 文件中的实际行号，含注释行
+			return nil, fmt.Errorf("第%d行: %w", line, bad)
+		}
+		points = append(points, densityPoint{Temperature: v[0], Conc: v[1], Density: v[2]})
+	}
+}
+
+// Domain 浓度识别结果相对于密度表的适用范围
+type Domain string
+
+const (
+	InRange      Domain = "in_range"     // 表格范围内插值
+	Extrapolated Domain = "extrapolated" // 温度超出表格范围，按最近两条等温线外推
+	Clamped      Domain = "clamped"      // 密度超出表格范围，浓度已截断为边界值
+	Invalid      Domain = "invalid"      // 温度或密度无效，无法识别浓度
+)
+
+// 严重程度，由轻到重
+var domainSeverity = map[Domain]int{InRange: 0, Extrapolated: 1, Clamped: 2, Invalid: 3}
+
+// Warning 页面和接口展示的提示，表格范围内时为空
+func (d Domain) Warning() string {
+	switch d {
+	case Extrapolated:
+		return "温度超出表格范围，浓度为外推值"
+	case Clamped:
+		return "密度超出表格范围，浓度已截断"
+	case Invalid:
+		return "温度或密度无效，无法识别浓度"
+	}
+	return ""
+}
+
+// 取两者中更严重的
+func worse(a, b Domain) Domain {
+	if domainSeverity[b] > domainSeverity[a] {
+		return b
+	}
+	return a
+}
+
+// ConcResult 浓度识别结果
+type ConcResult struct {
+	Conc   float64 // 质量分数%
+	Domain Domain  // 适用范围
+}
+
+// Conc 双向线性插值：温度 + 密度 → 质量分数%。
+// 温度超出表格时按最近两条等温线外推，密度超出某温度的数据范围时截断为边界浓度，
+// 非法输入返回 Invalid，不会因传感器异常值而出错。
+func (t DensityTable) Conc(temp, density float64) ConcResult {
+	if !isFinite(temp) || !isFinite(density) || density <= 0 || len(t) == 0 {
+		return ConcResult{Domain: Invalid}
+	}
+
+	// 定位温度区间 t1 ≤ temp ≤ t2
+	keys := t.Temperatures()
+	n := len(keys)
+	domain := InRange
+	var t1, t2 float64
+	switch {
+	case n == 1:
+		t1, t2 = keys[0], keys[0]
+		if temp != keys[0] {
+			domain = Extrapolated
+		}
+	case temp < keys[0]:
+		t1, t2 = keys[0], keys[1]
+		domain = Extrapolated
+	case temp > keys[n-1]:
+		t1, t2 = keys[n-2], keys[n-1]
+		domain = Extrapolated
+	default:
+		i := sort.SearchFloat64s(keys, temp)
+		if keys[i] == temp {
+			t1, t2 = temp, temp
+		} else {
+			t1, t2 = keys[i-1], keys[i]
+		}
+	}
+
+	c1, d1 := interpRow(t[t1], density)
+	domain = worse(domain, d1)
+	if t1 == t2 {
+		return ConcResult{Conc: c1, Domain: domain}
+	}
+	c2, d2 := interpRow(t[t2], density)
+	domain = worse(domain, d2)
+	c := c1 + (c2-c1)/(t2-t1)*(temp-t1)
+	if c < 0 || c > 100 {
+		c = math.Min(math.Max(c, 0), 100)
+		domain = worse(domain, Clamped)
+	}
+	return ConcResult{Conc: c, Domain: domain}
+}
+
+// Density 反向插值：温度 + 质量分数% → 密度g/cm³。
+// 温度超出表格时取边界温度，浓度超出某温度的数据范围时按端部线段线性外推。
+func (t DensityTable) Density(temp, conc float64) float64 {
+	keys := t.Temperatures()
+	if len(keys) == 0 {
+		return 0
+	}
+	temp = math.Min(math.Max(temp, keys[0]), keys[len(keys)-1])
+	i := sort.SearchFloat64s(keys, temp)
+	if keys[i] == temp {
+		return densityAt(t[temp], conc)
+	}
+	t1, t2 := keys[i-1], keys[i]
+	d1, d2 := densityAt(t[t1], conc), densityAt(t[t2], conc)
+	return d1 + (d2-d1)/(t2-t1)*(temp-t1)
+}
+
+// 在给定温度下按浓度插值密度，超出范围按端部线段外推
+func densityAt(tbl [][2]float64, c float64) float64 {
+	if len(tbl) == 1 {
+		return tbl[0][1]
+	}
+	i := 1
+	for i < len(tbl)-1 && c > tbl[i][0] {
+		i++
+	}
+	a := (c - tbl[i-1][0]) / (tbl[i][0] - tbl[i-1][0])
+	return tbl[i-1][1] + a*(tbl[i][1]-tbl[i-1][1])
+}
+
+// 在给定温度下的密度-浓度表中插值
+func interpRow(tbl [][2]float64, d float64) (float64, Domain) {
+	if len(tbl) == 0 {
+		return 0, Invalid
+	}
+	for i := 0; i < len(tbl)-1; i++ {
+		if d >= tbl[i][1] && d <= tbl[i+1][1] {
+			a := (d - tbl[i][1]) / (tbl[i+1][1] - tbl[i][1])
+			return tbl[i][0] + a*(tbl[i+1][0]-tbl[i][0]), InRange
+		}
+	}
+	// 如果密度超出范围，返回边界值
+	if d < tbl[0][1] {
+		return tbl[0][0], Clamped
+	}
+	if d > tbl[len(tbl)-1][1] {
+		return tbl[len(tbl)-1][0], Clamped
+	}
+	return tbl[0][0], InRange // 只有一个数据点
+}
+
+func isFinite(x float64) bool {
+	return !math.IsNaN(x) && !math.IsInf(x, 0)
+}
@@ -0,0 +1,101 @@
+package property
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// 覆盖 20～100℃、0～50% 的最小密度表
+const validCSV = `# 测试密度表
+temperature,conc,density
+20,0,1.000
+20,50,1.500
+100,0,0.960
+100,50,1.450
+`
+
+func writeTemp(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadDensityTable(t *testing.T) {
+	tbl, err := LoadDensityTable(writeTemp(t, "ok.csv", validCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(tbl.Temperatures()); got != 2 {
+		t.Errorf("温度数 = %d, want 2", got)
+	}
+	if _, err := LoadDensityTable("../data/coso4_density.csv"); err != nil {
+		t.Errorf("随附密度表: %v", err)
+	}
+}
+
+func TestLoadDensityTableRejectsNonMonotonic(t *testing.T) {
+	csv := validCSV + "20,30,1.600\n" // 30% 的密度大于 50%
+	_, err := LoadDensityTable(writeTemp(t, "bad.csv", csv))
+	if err == nil || !strings.Contains(err.Error(), "密度不是严格递增") {
+		t.Errorf("非单调密度表应被拒绝，got %v", err)
+	}
+}
+
+func TestLoadDensityTableLineNumber(t *testing.T) {
+	// 第7行（含注释行和表头）数值非法
+	csv := validCSV + "20,x,1.600\n"
+	_, err := LoadDensityTable(writeTemp(t, "bad.csv", csv))
+	if err == nil || !strings.Contains(err.Error(), "第7行") {
+		t.Errorf("应报告第7行，got %v", err)
+	}
+}
+
+func TestDensityTableConc(t *testing.T) {
+	tbl := DensityTable{
+		20:  {{0, 1.000}, {50, 1.500}},
+		100: {{0, 0.960}, {50, 1.450}},
+	}
+	// 1.25 g/cm³：20℃ 为 25%，100℃ 为 (1.25−0.96)/0.0098 ≈ 29.59%
+	c100 := (1.25 - 0.96) / 0.0098
+	for _, c := range []struct {
+		name          string
+		temp, density float64
+		conc          float64
+		domain        Domain
+	}{
+		{"表格范围内", 60, 1.25, 25 + (c100-25)/2, InRange},
+		{"等温线上", 20, 1.25, 25, InRange},
+		{"低于20℃外推", 10, 1.25, 25 - (c100-25)/8, Extrapolated},
+		{"密度过高截断", 60, 2.0, 50, Clamped},
+		{"密度过低截断", 60, 0.5, 0, Clamped},
+		{"温度NaN", math.NaN(), 1.25, 0, Invalid},
+		{"密度Inf", 60, math.Inf(1), 0, Invalid},
+		{"密度为0", 60, 0, 0, Invalid},
+		{"密度为负", 60, -1, 0, Invalid},
+	} {
+		got := tbl.Conc(c.temp, c.density)
+		if got.Domain != c.domain || math.Abs(got.Conc-c.conc) > 1e-9 {
+			t.Errorf("%s: Conc(%g, %g) = %.6f %s, want %.6f %s", c.name, c.temp, c.density, got.Conc, got.Domain, c.conc, c.domain)
+		}
+	}
+}
+
+func TestLoadDensityTableMalformedFirstRow(t *testing.T) {
+	// 没有表头时，第一行数据有误不能当作表头跳过
+	csv := "# 无表头\n20,x,1.000\n20,50,1.500\n100,0,0.960\n100,50,1.450\n"
+	_, err := LoadDensityTable(writeTemp(t, "bad.csv", csv))
+	if err == nil || !strings.Contains(err.Error(), "第2行") {
+		t.Errorf("应报告第2行，got %v", err)
+	}
+	// 没有表头的合法文件
+	csv = "20,0,1.000\n20,50,1.500\n100,0,0.960\n100,50,1.450\n"
+	if _, err := LoadDensityTable(writeTemp(t, "ok.csv", csv)); err != nil {
+		t.Errorf("无表头文件: %v", err)
+	}
+}
@@ -0,0 +1,77 @@
+// Package property 溶液物性包：密度→浓度换算、溶解度、默认目标浓度、沸点升高、比热容。
+// 每种溶液实现 Package 接口并注册，评估时按名称选用，同一套部署可服务多种硫酸盐溶液。
+package property
+
+import (
+	"sort"
+
+	"test/steam"
+)
+
+// Package 溶液物性包，浓度均为该溶液约定的质量分数%（如硫酸钴为七水合物质量分数）
+type Package interface {
+	Name() string                                // 唯一名称，用于选择，如 "CoSO4"
+	Label() string                               // 页面显示名称
+	Conc(temp, density float64) ConcResult       // 温度℃ + 密度g/cm³ → 质量分数%及适用范围
+	Density(temp, conc float64) float64          // 温度℃ + 质量分数% → 密度g/cm³
+	Formula() Formula                            // 溶质化学组成，用于浓度单位换算
+	SolubilityLimit(temp float64) float64        // 该温度下的饱和浓度 %
+	DefaultTargetConc() float64                  // 默认目标浓度 %
+	BoilingPointRise(conc, temp float64) float64 // 沸点升高 ℃，temp 为二次蒸汽（纯水）饱和温度℃
+	HeatCapacity(conc, temp float64) float64     // 溶液比热容 kJ/(kg·K)
+}
+
+// DefaultName 默认物性包
+const DefaultName = "CoSO4"
+
+var registry = map[string]Package{}
+
+// Register 注册物性包，名称重复时覆盖
+func Register(p Package) {
+	registry[p.Name()] = p
+}
+
+// Get 按名称查找物性包，名称为空时返回默认物性包
+func Get(name string) (Package, bool) {
+	if name == "" {
+		name = DefaultName
+	}
+	p, ok := registry[name]
+	return p, ok
+}
+
+// List 已注册的物性包，按名称排序
+func List() []Package {
+	out := make([]Package, 0, len(registry))
+	for _, p := range registry {
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name() < out[j].Name() })
+	return out
+}
+
+// 气体常数 J/(mol·K)
+const gasConstant = 8.314462
+
+// WaterHeatCapacity 液态水比热容 kJ/(kg·K)，20～100℃内变化不超过1%，取定值
+const WaterHeatCapacity = 4.187
+
+// EbullioscopicConstant 水的沸点升高常数 Kb = R·T²/r ℃·kg/mol，temp 为纯水沸点℃（常压下约0.513）
+func EbullioscopicConstant(temp float64) float64 {
+	T := temp + 273.15
+	return gasConstant * T * T / (steam.LatentHeat(temp) * 1000)
+}
+
+// 分段线性插值，xs 升序，超出范围取边界值
+func interpolate(xs, ys []float64, x float64) float64 {
+	if x <= xs[0] {
+		return ys[0]
+	}
+	for i := 1; i < len(xs); i++ {
+		if x <= xs[i] {
+			a := (x - xs[i-1]) / (xs[i] - xs[i-1])
+			return ys[i-1] + a*(ys[i]-ys[i-1])
+		}
+	}
+	return ys[len(ys)-1]
+}
@@ -0,0 +1,107 @@
+package property
+
+import "fmt"
+
+// Formula 溶质化学组成：浓度基准形态（结晶水合物）、无水盐及金属元素的摩尔质量
+type Formula struct {
+	Hydrate            string  // 浓度基准形态，如 "CoSO4·7H2O"
+	Anhydrous          string  // 无水盐，如 "CoSO4"
+	Metal              string  // 金属元素，如 "Co"
+	HydrateMolarMass   float64 // g/mol
+	AnhydrousMolarMass float64 // g/mol
+	MetalMolarMass     float64 // g/mol
+}
+
+// Unit 浓度单位
+type Unit string
+
+const (
+	UnitHydrate   Unit = "hydrate"   // 水合物质量分数 %（质量平衡基准）
+	UnitAnhydrous Unit = "anhydrous" // 无水盐质量分数 %
+	UnitMetal     Unit = "metal"     // 金属质量分数 wt%
+	UnitMetalGL   Unit = "metal_gl"  // 金属质量浓度 g/L（需要密度）
+)
+
+// Units 全部浓度单位
+var Units = []Unit{UnitHydrate, UnitAnhydrous, UnitMetal, UnitMetalGL}
+
+// ParseUnit 解析单位名称，为空时为水合物质量分数
+func ParseUnit(s string) (Unit, error) {
+	if s == "" {
+		return UnitHydrate, nil
+	}
+	for _, u := range Units {
+		if string(u) == s {
+			return u, nil
+		}
+	}
+	return "", fmt.Errorf("未知浓度单位: %s", s)
+}
+
+// NeedsDensity 换算是否需要溶液密度
+func (u Unit) NeedsDensity() bool {
+	return u == UnitMetalGL
+}
+
+// Label 单位显示名称，如 "CoSO4·7H2O %"、"Co g/L"
+func (f Formula) Label(u Unit) string {
+	switch u {
+	case UnitAnhydrous:
+		return f.Anhydrous + " %"
+	case UnitMetal:
+		return f.Metal + " wt%"
+	case UnitMetalGL:
+		return f.Metal + " g/L"
+	}
+	return f.Hydrate + " %"
+}
+
+// 单位相对水合物质量分数的换算系数（g/L 另乘密度）
+func (f Formula) factor(u Unit) float64 {
+	switch u {
+	case UnitAnhydrous:
+		return f.AnhydrousMolarMass / f.HydrateMolarMass
+	case UnitMetal, UnitMetalGL:
+		return f.MetalMolarMass / f.HydrateMolarMass
+	}
+	return 1
+}
+
+// FromHydrate 水合物质量分数% → 指定单位，density 为溶液密度 g/cm³（仅 g/L 需要）
+func (f Formula) FromHydrate(u Unit, conc, density float64) float64 {
+	v := conc * f.factor(u)
+	if u == UnitMetalGL {
+		v *= density * 10 // wt% × g/cm³ × 1000 / 100
+	}
+	return v
+}
+
+// ToHydrate 指定单位 → 水合物质量分数%，density 为溶液密度 g/cm³（仅 g/L 需要）
+func (f Formula) ToHydrate(u Unit, v, density float64) float64 {
+	if u == UnitMetalGL {
+		if density <= 0 {
+			return 0
+		}
+		v /= density * 10
+	}
+	return v / f.factor(u)
+}
+
+// HydrateFromMetalGL 金属 g/L → 水合物质量分数%，未知密度时按密度表迭代求解
+func HydrateFromMetalGL(p Package, gl, temp float64) (conc, density float64) {
+	f := p.Formula()
+	density = 1.0
+	for i := 0; i < 50; i++ {
+		conc = f.ToHydrate(UnitMetalGL, gl, density)
+		next := p.Density(temp, conc)
+		if next <= 0 {
+			break
+		}
+		if d := next - density; d < 1e-7 && d > -1e-7 {
+			density = next
+			break
+		}
+		density = next
+	}
+	return f.ToHydrate(UnitMetalGL, gl, density), density
+}
@@ -0,0 +1,54 @@
+package scenario
+
+import "test/evaluator"
+
+// 差值绝对值不超过此值时视为无变化，不高亮
+const DeltaEpsilon = 0.005
+
+// Column 对比中的一个情景：评估结果及相对基准情景（第一个）的差值
+type Column struct {
+	Name            string                // 情景名称
+	Error           string                `json:",omitempty"` // 评估失败原因
+	Result          evaluator.PlantResult // 评估结果
+	DTotalQset      float64               // ΣQset 差值 t/h
+	DTheoreticalMax float64               // 理论最大投料量差值 t/h
+	Effects         []EffectDelta         // 各效差值
+}
+
+// EffectDelta 单效相对基准情景的差值，基准情景没有该效时差值为0
+type EffectDelta struct {
+	Name     string  // 效名称
+	ConcOut  float64 // 出料浓度 %
+	DConcOut float64 // 出料浓度差值
+	Health   float64 // 健康度
+	DHealth  float64 // 健康度差值
+}
+
+// Compare 逐个评估情景，以第一个情景为基准计算差值
+func Compare(scenarios []Scenario) []Column {
+	cols := make([]Column, len(scenarios))
+	for i, sc := range scenarios {
+		c := &cols[i]
+		c.Name = sc.Name
+		res, err := evaluator.Evaluate(sc.Input)
+		c.Result = res
+		if err != nil {
+			c.Error = err.Error()
+			continue
+		}
+		base := cols[0].Result
+		if cols[0].Error == "" {
+			c.DTotalQset = res.TotalQset - base.TotalQset
+			c.DTheoreticalMax = res.TheoreticalMax - base.TheoreticalMax
+		}
+		for j, e := range res.Effects {
+			d := EffectDelta{Name: e.Name, ConcOut: e.ConcOut, Health: e.Health}
+			if cols[0].Error == "" && j < len(base.Effects) {
+				d.DConcOut = e.ConcOut - base.Effects[j].ConcOut
+				d.DHealth = e.Health - base.Effects[j].Health
+			}
+			c.Effects = append(c.Effects, d)
+		}
+	}
+	return cols
+}
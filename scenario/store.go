@@ -0,0 +1,125 @@
+// Package scenario 命名情景：保存一整套评估输入，便于对比不同操作方案。
+package scenario
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"sync"
+	"time"
+
+	"test/evaluator"
+)
+
+// Scenario 命名情景
+type Scenario struct {
+	Name     string               // 情景名称，唯一
+	Time     time.Time            // 保存时间
+	Operator string               // 操作员
+	Input    evaluator.PlantInput // 完整输入参数
+}
+
+// Store 情景存储，整体保存为一个JSON文件，每次修改后重写
+type Store struct {
+	mu        sync.RWMutex
+	path      string
+	scenarios map[string]Scenario
+}
+
+// Open 打开（不存在时创建）情景文件
+func Open(path string) (*Store, error) {
+	s := &Store{path: path, scenarios: map[string]Scenario{}}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var list []Scenario
+	if err := json.Unmarshal(b, &list); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, sc := range list {
+		s.scenarios[sc.Name] = sc
+	}
+	return s, nil
+}
+
+// Save 保存情景，同名情景被覆盖
+func (s *Store) Save(sc Scenario) (Scenario, error) {
+	if sc.Name == "" {
+		return Scenario{}, errors.New("情景名称不能为空")
+	}
+	if sc.Time.IsZero() {
+		sc.Time = time.Now()
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, existed := s.scenarios[sc.Name]
+	s.scenarios[sc.Name] = sc
+	if err := s.write(); err != nil {
+		if existed {
+			s.scenarios[sc.Name] = old
+		} else {
+			delete(s.scenarios, sc.Name)
+		}
+		return Scenario{}, err
+	}
+	return sc, nil
+}
+
+// Delete 删除情景，不存在时返回 false
+func (s *Store) Delete(name string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	old, ok := s.scenarios[name]
+	if !ok {
+		return false, nil
+	}
+	delete(s.scenarios, name)
+	if err := s.write(); err != nil {
+		s.scenarios[name] = old
+		return true, err
+	}
+	return true, nil
+}
+
+// Get 按名称查找情景
+func (s *Store) Get(name string) (Scenario, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	sc, ok := s.scenarios[name]
+	return sc, ok
+}
+
+// List 全部情景，按名称排序
+func (s *Store) List() []Scenario {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.sorted()
+}
+
+func (s *Store) sorted() []Scenario {
+	out := make([]Scenario, 0, len(s.scenarios))
+	for _, sc := range s.scenarios {
+		out = append(out, sc)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// 先写临时文件再重命名，避免写入中断损坏原文件；调用方持有写锁
+func (s *Store) write() error {
+	b, err := json.MarshalIndent(s.sorted(), "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, s.path)
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net/http"
+	"time"
+
+	"test/evaluator"
+	"test/scenario"
+)
+
+// 命名情景
+var scenarioStore *scenario.Store
+
+// 一次对比的情景数量范围
+const (
+	minCompare = 2
+	maxCompare = 4
+)
+
+// 保存当前输入为命名情景
+func saveScenario(name, operator string, in evaluator.PlantInput) (scenario.Scenario, error) {
+	return scenarioStore.Save(scenario.Scenario{Name: name, Time: time.Now(), Operator: operator, Input: in})
+}
+
+// 按名称查找并对比情景，数量需在 minCompare～maxCompare 之间
+func compareScenarios(names []string) ([]scenario.Column, error) {
+	if len(names) < minCompare || len(names) > maxCompare {
+		return nil, errors.New("请选择2～4个情景进行对比")
+	}
+	var list []scenario.Scenario
+	for _, name := range names {
+		sc, ok := scenarioStore.Get(name)
+		if !ok {
+			return nil, errors.New("情景不存在: " + name)
+		}
+		list = append(list, sc)
+	}
+	return scenario.Compare(list), nil
+}
+
+type ComparePageData struct {
+	Error      string
+	Scenarios  []scenario.Scenario // 全部情景，供选择
+	Selected   map[string]bool     // 已选情景
+	Columns    []scenario.Column   // 对比结果，第一个为基准
+	EffectRows []int               // 各情景中最多的效数，用于逐效对比行
+}
+
+func compareHandler(w http.ResponseWriter, r *http.Request) {
+	r.ParseForm()
+	names := r.Form["name"]
+	data := ComparePageData{Scenarios: scenarioStore.List(), Selected: map[string]bool{}}
+	for _, n := range names {
+		data.Selected[n] = true
+	}
+	if len(names) > 0 {
+		cols, err := compareScenarios(names)
+		if err != nil {
+			data.Error = err.Error()
+		}
+		data.Columns = cols
+	}
+	for _, c := range data.Columns {
+		for len(data.EffectRows) < len(c.Effects) {
+			data.EffectRows = append(data.EffectRows, len(data.EffectRows))
+		}
+	}
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>情景对比</title>
+    <style>
+        body{font-family:Arial;margin:20px;background:#f8f8f8;}
+        .header{background:#4CAF50;color:white;padding:15px;text-align:center;margin-bottom:20px;}
+        .summary{background:white;padding:15px;border-radius:8px;margin-bottom:20px;box-shadow: 0 2px 4px rgba(0,0,0,0.1);}
+        .error{background:#f8d7da;color:#721c24;padding:10px;border-radius:4px;margin-bottom:20px;}
+        .up{background:#d4edda;}
+        .down{background:#f8d7da;}
+        table{width:100%;border-collapse:collapse;margin:10px 0;}
+        td,th{border:1px solid #ccc;padding:6px;text-align:center;font-size:14px;}
+        th{background:#e8f4fd;}
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>情景对比</h1>
+        <p>选择2～4个情景，以第一个为基准显示差值 | <a href="/" style="color:white;">返回评估页面</a></p>
+    </div>
+
+    {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+
+    <div class="summary">
+        <form method="GET">
+            <table>
+                <tr><th>选择</th><th>情景名称</th><th>保存时间</th><th>操作员</th><th>进料浓度 / 流量</th></tr>
+                {{range .Scenarios}}
+                <tr>
+                    <td><input type="checkbox" name="name" value="{{.Name}}" {{if index $.Selected .Name}}checked{{end}}></td>
+                    <td><a href="/?scenario={{.Name}}">{{.Name}}</a></td>
+                    <td>{{.Time.Format "2006-01-02 15:04:05"}}</td>
+                    <td>{{.Operator}}</td>
+                    <td>{{printf "%.2f" .Input.FeedConc}} / {{printf "%.1f" .Input.ActualFlow}}</td>
+                </tr>
+                {{else}}
+                <tr><td colspan="5">暂无情景，请在评估页面填写情景名称后保存</td></tr>
+                {{end}}
+            </table>
+            <input type="submit" value="对比">
+        </form>
+    </div>
+
+    {{if .Columns}}
+    <div class="summary">
+        <table>
+            <tr><th>项目</th>{{range .Columns}}<th>{{.Name}}</th>{{end}}</tr>
+            <tr><td>评估</td>{{range .Columns}}<td {{if .Error}}class="down"{{end}}>{{if .Error}}{{.Error}}{{else}}正常{{end}}</td>{{end}}</tr>
+            <tr><td>系统峰值脱水能力 ΣQ_set t/h</td>{{range $i, $c := .Columns}}<td class="{{trend $c.DTotalQset}}">{{printf "%.2f" $c.Result.TotalQset}}{{if $i}}<br><small>{{printf "%+.2f" $c.DTotalQset}}</small>{{end}}</td>{{end}}</tr>
+            <tr><td>理论最大投料量 t/h</td>{{range $i, $c := .Columns}}<td class="{{trend $c.DTheoreticalMax}}">{{printf "%.1f" $c.Result.TheoreticalMax}}{{if $i}}<br><small>{{printf "%+.1f" $c.DTheoreticalMax}}</small>{{end}}</td>{{end}}</tr>
+            {{range $j := .EffectRows}}
+            <tr><td>第{{add $j 1}}效 出料浓度 ConcOut %</td>{{range $i, $c := $.Columns}}<td {{if lt $j (len $c.Effects)}}class="{{trend (index $c.Effects $j).DConcOut}}"{{end}}>{{if lt $j (len $c.Effects)}}{{with index $c.Effects $j}}{{printf "%.2f" .ConcOut}}{{if $i}}<br><small>{{printf "%+.2f" .DConcOut}}</small>{{end}}{{end}}{{else}}-{{end}}</td>{{end}}</tr>
+            <tr><td>第{{add $j 1}}效 健康度 Health</td>{{range $i, $c := $.Columns}}<td {{if lt $j (len $c.Effects)}}class="{{trend (index $c.Effects $j).DHealth}}"{{end}}>{{if lt $j (len $c.Effects)}}{{with index $c.Effects $j}}{{printf "%.2f" .Health}}{{if $i}}<br><small>{{printf "%+.2f" .DHealth}}</small>{{end}}{{end}}{{else}}-{{end}}</td>{{end}}</tr>
+            {{end}}
+        </table>
+    </div>
+    {{end}}
+</body>
+</html>
+	`
+	tmplParsed := template.Must(template.New("compare").Funcs(templateFuncs).Parse(tmpl))
+	tmplParsed.Execute(w, data)
+}
+
+// GET /api/v1/scenarios：全部情景
+// POST /api/v1/scenarios：保存情景，字段与 /api/v1/evaluate 一致，另加情景名称 name
+func apiScenariosHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, scenarioStore.List())
+	case http.MethodPost:
+		var body jsonSource
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "请求体不是合法的JSON: "+err.Error())
+			return
+		}
+		in, err := parseInput(body)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sc, err := saveScenario(body.Value("name"), body.Value("operator"), in)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, sc)
+	default:
+		w.Header().Set("Allow", "GET, POST")
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET、POST请求")
+	}
+}
+
+// GET /api/v1/scenarios/{name}：单个情景
+// DELETE /api/v1/scenarios/{name}：删除情景
+func apiScenarioHandler(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("name")
+	switch r.Method {
+	case http.MethodGet:
+		sc, ok := scenarioStore.Get(name)
+		if !ok {
+			writeJSONError(w, http.StatusNotFound, "情景不存在: "+name)
+			return
+		}
+		writeJSON(w, http.StatusOK, sc)
+	case http.MethodDelete:
+		ok, err := scenarioStore.Delete(name)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "删除情景失败: "+err.Error())
+			return
+		}
+		if !ok {
+			writeJSONError(w, http.StatusNotFound, "情景不存在: "+name)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.Header().Set("Allow", "GET, DELETE")
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET、DELETE请求")
+	}
+}
+
+// GET /api/v1/compare?name=A&name=B：对比2～4个情景
+func apiCompareHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	cols, err := compareScenarios(r.URL.Query()["name"])
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, cols)
+}
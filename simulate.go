@@ -0,0 +1,280 @@
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"time"
+
+	"test/evaluator"
+)
+
+// 稳态模拟默认生蒸汽温度和冷凝器温度 ℃，与评估页面默认预设参数相当
+const (
+	defaultSimSteamTemp     = 116.0
+	defaultSimCondenserTemp = 60.0
+)
+
+type SimPageData struct {
+	Time     string
+	Error    string                  `json:",omitempty"` // 输入参数错误或求解失败
+	Packages []propertyOption        `json:"-"`          // 可选物性包
+	Specs    []evaluator.ProductSpec `json:"-"`          // 可选产品规格
+	Spec     string                  `json:"-"`          // 所选产品规格
+	Input    evaluator.SimInput      `json:"-"`          // 输入参数，用于页面回显
+	evaluator.SimResult
+}
+
+// 模拟默认参数：设备参数取评估页面默认预设，健康度取最近一次评估结果
+func defaultSimInput() evaluator.SimInput {
+	plant := defaultInput()
+	in := evaluator.SimInput{
+		Property:      plant.Property,
+		FeedFlow:      plant.ActualFlow,
+		FeedConc:      plant.FeedConc,
+		SteamTemp:     defaultSimSteamTemp,
+		CondenserTemp: defaultSimCondenserTemp,
+	}
+	for _, e := range plant.Effects {
+		in.Effects = append(in.Effects, evaluator.SimEffectInput{Qnom: e.Qnom, DtDesign: e.DtDesign, Health: 1})
+	}
+	if recs := store.List(time.Time{}, time.Time{}); len(recs) > 0 {
+		for i, e := range recs[0].Result.Effects {
+			if i < len(in.Effects) && e.Health > 0 {
+				in.Effects[i].Health = min(e.Health, 1)
+			}
+		}
+	}
+	return in
+}
+
+// 在默认参数基础上读取模拟输入，字段名与评估页面一致，健康度为 health_N
+func parseSimInput(src paramSource) (evaluator.SimInput, error) {
+	in := defaultSimInput()
+	if name := src.Value("property"); name != "" {
+		in.Property = name
+	}
+	spec, specErr := lookupSpec(src.Value("spec"))
+	if specErr == nil {
+		in.TargetConc = spec.TargetConc
+	}
+	readFloat(src, "actual_flow", &in.FeedFlow)
+	readFloat(src, "feed_conc", &in.FeedConc)
+	readFloat(src, "feed_temp", &in.FeedTemp)
+	readFloat(src, "steam_p", &in.SteamPressure)
+	readFloat(src, "steam_temp", &in.SteamTemp)
+	readFloat(src, "cond_temp", &in.CondenserTemp)
+	if a := src.Value("feed_arrangement"); a != "" {
+		in.FeedArrangement = evaluator.FeedArrangement(a)
+	}
+	in.FeedOrder = parseOrder(src.Value("feed_order"))
+
+	num := len(in.Effects)
+	var count float64
+	readFloat(src, "effects", &count)
+	if count >= 1 {
+		num = int(min(count, maxEffects))
+	}
+	effects := make([]evaluator.SimEffectInput, num)
+	copy(effects, in.Effects)
+	in.Effects = effects
+	for i := range in.Effects {
+		e := &in.Effects[i]
+		n := strconv.Itoa(i + 1)
+		readFloat(src, "qnom_"+n, &e.Qnom)
+		readFloat(src, "dt_design_"+n, &e.DtDesign)
+		readFloat(src, "health_"+n, &e.Health)
+		readFloat(src, "split_"+n, &e.FeedShare)
+	}
+	return in, specErr
+}
+
+// POST /api/v1/simulate：稳态模拟，字段与模拟页面表单一致
+func apiSimulateHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持POST请求")
+		return
+	}
+	var body jsonSource
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "请求体不是合法的JSON: "+err.Error())
+		return
+	}
+	in, err := parseSimInput(body)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	result, err := evaluator.Simulate(in)
+	if err != nil {
+		writeJSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, SimPageData{Time: time.Now().Format("2006-01-02 15:04:05"), SimResult: result})
+}
+
+func simulateHandler(w http.ResponseWriter, r *http.Request) {
+	in := defaultSimInput()
+	var err error
+	if r.Method == "POST" {
+		in, err = parseSimInput(formSource{r})
+	}
+	result, simErr := evaluator.Simulate(in)
+	if err == nil {
+		err = simErr
+	}
+	data := SimPageData{
+		Time:      time.Now().Format("2006-01-02 15:04:05"),
+		Packages:  propertyOptions(),
+		Specs:     specs,
+		Spec:      r.FormValue("spec"),
+		Input:     in,
+		SimResult: result,
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>稳态模拟</title>
+    <style>
+        body{font-family:Arial;margin:20px;background:#f8f8f8;}
+        .header{background:#4CAF50;color:white;padding:15px;text-align:center;margin-bottom:20px;}
+        .summary{background:white;padding:15px;border-radius:8px;margin-bottom:20px;box-shadow: 0 2px 4px rgba(0,0,0,0.1);}
+        .highlight{background:#fff3cd;font-weight:bold;}
+        .critical{background:#f5c6cb !important; color: #721c24; font-weight: bold;}
+        .error{background:#f8d7da;color:#721c24;padding:10px;border-radius:4px;margin-bottom:20px;}
+        .warning{background:#fff3cd;color:#856404;padding:10px;border-radius:4px;margin-bottom:20px;}
+        table{width:100%;border-collapse:collapse;margin:10px 0;}
+        td,th{border:1px solid #ccc;padding:6px;text-align:center;font-size:14px;}
+        th{background:#e8f4fd;}
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>多效蒸发稳态模拟</h1>
+        <p>给定进料、生蒸汽和冷凝器条件，按各效热量、质量衡算预测出料 | <a href="/" style="color:white;">返回评估页面</a></p>
+    </div>
+
+    {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+    {{if .Warnings}}<div class="warning"><strong>注意：</strong>{{range .Warnings}}<div>{{.}}</div>{{end}}</div>{{end}}
+
+    <form method="POST">
+        <div class="summary">
+            <h3>运行条件</h3>
+            <table>
+                <tr>
+                    <td>进料质量流量</td>
+                    <td><input name="actual_flow" value="{{printf "%.2f" .Input.FeedFlow}}" step="0.1"> t/h</td>
+                    <td>进料浓度</td>
+                    <td><input name="feed_conc" value="{{printf "%.2f" .Input.FeedConc}}" step="0.1"> %</td>
+                </tr>
+                <tr>
+                    <td>进料温度（可选）</td>
+                    <td><input name="feed_temp" value="{{if .Input.FeedTemp}}{{printf "%.1f" .Input.FeedTemp}}{{end}}" step="0.1"> ℃</td>
+                    <td>冷凝器温度</td>
+                    <td><input name="cond_temp" value="{{printf "%.1f" .Input.CondenserTemp}}" step="0.1"> ℃</td>
+                </tr>
+                <tr>
+                    <td>生蒸汽绝对压力（优先）</td>
+                    <td><input name="steam_p" value="{{if .Input.SteamPressure}}{{printf "%.1f" .Input.SteamPressure}}{{end}}" step="0.1"> kPa</td>
+                    <td>生蒸汽温度</td>
+                    <td><input name="steam_temp" value="{{if .Input.SteamTemp}}{{printf "%.1f" .Input.SteamTemp}}{{end}}" step="0.1"> ℃</td>
+                </tr>
+                <tr>
+                    <td>进料流程</td>
+                    <td>
+                        <select name="feed_arrangement">
+                            <option value="forward" {{if eq .FeedArrangement "forward"}}selected{{end}}>顺流（I → N）</option>
+                            <option value="backward" {{if eq .FeedArrangement "backward"}}selected{{end}}>逆流（N → I）</option>
+                            <option value="parallel" {{if eq .FeedArrangement "parallel"}}selected{{end}}>平流（各效分别进料）</option>
+                            <option value="mixed" {{if eq .FeedArrangement "mixed"}}selected{{end}}>错流（自定义顺序）</option>
+                        </select>
+                        <br>错流顺序 <input name="feed_order" value="{{if eq .FeedArrangement "mixed"}}{{range $i, $n := .Input.FeedOrder}}{{if $i}},{{end}}{{$n}}{{end}}{{end}}" placeholder="如 2,3,1">
+                    </td>
+                    <td>物性包 / 产品规格</td>
+                    <td>
+                        <select name="property">
+                            {{range .Packages}}<option value="{{.Name}}" {{if eq .Name $.Property}}selected{{end}}>{{.Label}}</option>{{end}}
+                        </select>
+                        <select name="spec">
+                            {{range .Specs}}<option value="{{.Name}}" {{if eq .Name $.Spec}}selected{{end}}>{{.Name}}</option>{{end}}
+                        </select>
+                    </td>
+                </tr>
+                <tr>
+                    <td>效数</td>
+                    <td><input name="effects" value="{{len .Input.Effects}}" step="1" min="1"> 效</td>
+                    <td>当前时间</td>
+                    <td>{{.Time}}</td>
+                </tr>
+            </table>
+            <table>
+                <tr><th>效</th><th>厂家预设换热能力 kW</th><th>预设温差 ℃</th><th>健康度（默认取最近一次评估）</th><th>平流分配比例</th></tr>
+                {{range $i, $e := .Input.Effects}}{{$n := add $i 1}}
+                <tr>
+                    <td>{{$n}}</td>
+                    <td><input name="qnom_{{$n}}" value="{{printf "%.0f" $e.Qnom}}" step="10"></td>
+                    <td><input name="dt_design_{{$n}}" value="{{printf "%.1f" $e.DtDesign}}" step="0.1"></td>
+                    <td><input name="health_{{$n}}" value="{{printf "%.2f" $e.Health}}" step="0.01"></td>
+                    <td><input name="split_{{$n}}" value="{{if $e.FeedShare}}{{printf "%.2f" $e.FeedShare}}{{end}}" step="0.01"></td>
+                </tr>
+                {{end}}
+            </table>
+        </div>
+
+        {{if .Effects}}
+        <div class="summary">
+            <h3>模拟结果（{{.FeedArrangement.Label}}，牛顿迭代 {{.Iterations}} 次）</h3>
+            <table>
+                <tr>
+                    <td>生蒸汽温度 / 消耗</td>
+                    <td>{{printf "%.1f" .SteamTemp}} ℃ / {{printf "%.2f" .SteamFlow}} t/h</td>
+                    <td>总蒸发量 / 蒸汽经济性</td>
+                    <td>{{printf "%.2f" .TotalEvaporation}} t/h / {{printf "%.2f" .SteamEconomy}}</td>
+                </tr>
+                <tr>
+                    <td>完成液流量</td>
+                    <td class="highlight">{{printf "%.2f" .ProductFlow}} t/h</td>
+                    <td>完成液浓度 / 目标浓度</td>
+                    <td class="highlight">{{printf "%.2f" .ProductConc}} % / {{printf "%.2f" .TargetConc}} %</td>
+                </tr>
+            </table>
+            <table>
+                <tr><th>效</th><th>UA kW/K</th><th>加热蒸汽 ℃</th><th>出料温度 TempOut ℃</th><th>沸点升高 ℃</th><th>热负荷 kW</th><th>蒸发量 t/h</th><th>进料 t/h / %</th><th>出料 t/h</th><th>出料浓度 ConcOut %</th><th>出料密度 g/cm³</th><th>饱和浓度 %</th></tr>
+                {{range .FlowOrder}}{{with index $.Effects (add . -1)}}
+                <tr>
+                    <td>{{.Name}}</td>
+                    <td>{{printf "%.1f" .UA}}</td>
+                    <td>{{printf "%.1f" .HeatingTemp}}</td>
+                    <td class="highlight">{{printf "%.1f" .TempOut}}</td>
+                    <td>{{printf "%.2f" .BPE}}</td>
+                    <td>{{printf "%.0f" .Duty}}</td>
+                    <td>{{printf "%.2f" .Vapor}}</td>
+                    <td>{{printf "%.2f" .FlowIn}} / {{printf "%.2f" .ConcIn}}</td>
+                    <td>{{printf "%.2f" .FlowOut}}</td>
+                    <td class="highlight">{{printf "%.2f" .ConcOut}}</td>
+                    <td>{{printf "%.3f" .DensOut}}</td>
+                    <td {{if .CrystalRisk}}class="critical"{{end}}>{{printf "%.2f" .Solubility}}</td>
+                </tr>
+                {{end}}{{end}}
+            </table>
+        </div>
+        {{end}}
+
+        <div style="text-align:center; padding:20px;">
+            <input type="submit" value="模拟计算" style="padding:10px 30px;font-size:16px;">
+        </div>
+    </form>
+</body>
+</html>
+	`
+	tmplParsed := template.Must(template.New("simulate").Funcs(templateFuncs).Parse(tmpl))
+	tmplParsed.Execute(w, data)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"test/evaluator"
+)
+
+// 产品规格配置文件路径，为空时仅使用默认规格
+var specsPath string
+
+// 可选产品规格，第一个为默认规格
+var specs = []evaluator.ProductSpec{evaluator.DefaultSpec()}
+
+// 产品规格配置文件中的一项，推荐系数未填写时取默认值
+type specConfig struct {
+	Name          string  `json:"name"`           // 规格名称
+	Property      string  `json:"property"`       // 适用物性包，可选
+	TargetConc    float64 `json:"target_conc"`    // 目标浓度 %，为0时使用物性包默认目标浓度
+	LowFactor     float64 `json:"recommend_low"`  // 推荐下限系数
+	HighFactor    float64 `json:"recommend_high"` // 推荐上限系数
+	SuggestFactor float64 `json:"suggest"`        // 建议设定值系数
+}
+
+// 读取产品规格配置（JSON数组），规格名称不可重复
+func loadSpecs(path string) ([]evaluator.ProductSpec, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg []specConfig
+	if err := json.Unmarshal(b, &cfg); err != nil {
+		return nil, fmt.Errorf("解析 %s: %w", path, err)
+	}
+	if len(cfg) == 0 {
+		return nil, fmt.Errorf("%s 中没有产品规格", path)
+	}
+	seen := make(map[string]bool)
+	out := make([]evaluator.ProductSpec, 0, len(cfg))
+	for _, c := range cfg {
+		if c.Name == "" {
+			return nil, fmt.Errorf("产品规格缺少名称")
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("产品规格 %s 重复", c.Name)
+		}
+		seen[c.Name] = true
+		s := evaluator.ProductSpec(c)
+		if err := s.Validate(); err != nil {
+			return nil, err
+		}
+		out = append(out, s)
+	}
+	return out, nil
+}
+
+// 启动时加载产品规格配置，失败时仅使用默认规格
+func initSpecs() {
+	if specsPath == "" {
+		return
+	}
+	s, err := loadSpecs(specsPath)
+	if err != nil {
+		log.Printf("加载产品规格失败，使用默认规格: %v", err)
+		return
+	}
+	specs = s
+	log.Printf("已加载产品规格 %s（%d 个）", specsPath, len(s))
+}
+
+// 按名称查找产品规格，名称为空时返回默认规格
+func lookupSpec(name string) (evaluator.ProductSpec, error) {
+	if name == "" {
+		return specs[0], nil
+	}
+	for _, s := range specs {
+		if s.Name == name {
+			return s, nil
+		}
+	}
+	return evaluator.ProductSpec{}, fmt.Errorf("未知产品规格: %s", name)
+}
+
+// GET /api/v1/specs：可选产品规格
+func apiSpecsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持GET请求")
+		return
+	}
+	writeJSON(w, http.StatusOK, specs)
+}
@@ -0,0 +1,92 @@
+// Package steam 饱和水/饱和蒸汽物性，采用 IAPWS 饱和线辅助方程（Wagner & Pruß, 1993），
+// 适用于三相点至临界点，无需联网查表。
+package steam
+
+import "math"
+
+// 临界参数与适用范围
+const (
+	CriticalTemperature = 647.096 // K
+	CriticalPressure    = 22064.0 // kPa
+	CriticalDensity     = 322.0   // kg/m³
+	TripleTemperature   = 273.16  // K
+
+	celsiusToKelvin = 273.15
+)
+
+// 饱和蒸汽压方程系数
+var pa = [6]float64{-7.85951783, 1.84408259, -11.7866497, 22.6807411, -15.9618719, 1.80122502}
+
+// 饱和液体密度方程系数
+var rl = [6]float64{1.99274064, 1.09965342, -0.510839303, -1.75493479, -45.5170352, -6.74694450e5}
+
+// 饱和蒸汽密度方程系数
+var rv = [6]float64{-2.03150240, -2.68302940, -5.38626492, -17.2991605, -44.7586581, -63.9201063}
+
+// InRange 温度℃是否在饱和线方程适用范围内（三相点至临界点）
+func InRange(tC float64) bool {
+	T := tC + celsiusToKelvin
+	return T >= TripleTemperature && T < CriticalTemperature
+}
+
+// 温度℃ → 约化温度差 τ = 1 − T/Tc，超出适用范围时取边界值
+func tau(tC float64) (T, tau float64) {
+	T = tC + celsiusToKelvin
+	T = math.Min(math.Max(T, TripleTemperature), CriticalTemperature)
+	return T, 1 - T/CriticalTemperature
+}
+
+// SaturationPressure 饱和蒸汽压 kPa
+func SaturationPressure(tC float64) float64 {
+	T, t := tau(tC)
+	s := pa[0]*t + pa[1]*math.Pow(t, 1.5) + pa[2]*math.Pow(t, 3) + pa[3]*math.Pow(t, 3.5) + pa[4]*math.Pow(t, 4) + pa[5]*math.Pow(t, 7.5)
+	return CriticalPressure * math.Exp(CriticalTemperature/T*s)
+}
+
+// 饱和蒸汽压对温度的导数 kPa/K
+func dPdT(tC float64) float64 {
+	T, t := tau(tC)
+	p := SaturationPressure(tC)
+	ds := pa[0] + 1.5*pa[1]*math.Pow(t, 0.5) + 3*pa[2]*math.Pow(t, 2) + 3.5*pa[3]*math.Pow(t, 2.5) + 4*pa[4]*math.Pow(t, 3) + 7.5*pa[5]*math.Pow(t, 6.5)
+	return -p / T * (math.Log(p/CriticalPressure) + ds)
+}
+
+// LiquidDensity 饱和水密度 kg/m³
+func LiquidDensity(tC float64) float64 {
+	_, t := tau(tC)
+	return CriticalDensity * (1 + rl[0]*math.Pow(t, 1.0/3) + rl[1]*math.Pow(t, 2.0/3) + rl[2]*math.Pow(t, 5.0/3) +
+		rl[3]*math.Pow(t, 16.0/3) + rl[4]*math.Pow(t, 43.0/3) + rl[5]*math.Pow(t, 110.0/3))
+}
+
+// VaporDensity 饱和蒸汽密度 kg/m³
+func VaporDensity(tC float64) float64 {
+	_, t := tau(tC)
+	return CriticalDensity * math.Exp(rv[0]*math.Pow(t, 2.0/6)+rv[1]*math.Pow(t, 4.0/6)+rv[2]*math.Pow(t, 8.0/6)+
+		rv[3]*math.Pow(t, 18.0/6)+rv[4]*math.Pow(t, 37.0/6)+rv[5]*math.Pow(t, 71.0/6))
+}
+
+// LatentHeat 汽化潜热 kJ/kg，由克拉佩龙方程 r = T·(dp/dT)·(1/ρ” − 1/ρ') 计算
+func LatentHeat(tC float64) float64 {
+	T, _ := tau(tC)
+	return T * dPdT(tC) * (1/VaporDensity(tC) - 1/LiquidDensity(tC))
+}
+
+// SaturationTemperature 饱和温度℃，由饱和蒸汽压方程二分反解
+func SaturationTemperature(pKPa float64) float64 {
+	lo, hi := TripleTemperature-celsiusToKelvin, CriticalTemperature-celsiusToKelvin
+	if pKPa <= SaturationPressure(lo) {
+		return lo
+	}
+	if pKPa >= CriticalPressure {
+		return hi
+	}
+	for i := 0; i < 60; i++ {
+		mid := (lo + hi) / 2
+		if SaturationPressure(mid) < pKPa {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
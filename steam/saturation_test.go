@@ -0,0 +1,50 @@
+package steam
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLatentHeat(t *testing.T) {
+	// 100℃ 汽化潜热 2256.5 kJ/kg（IAPWS-IF97 蒸汽表）
+	if r := LatentHeat(100); math.Abs(r-2257) > 2 {
+		t.Errorf("LatentHeat(100) = %.1f, want ≈ 2257", r)
+	}
+	if r := LatentHeat(CriticalTemperature - celsiusToKelvin); r > 1e-6 {
+		t.Errorf("临界点汽化潜热 = %g, want 0", r)
+	}
+}
+
+func TestSaturationPressure(t *testing.T) {
+	// 100℃ 饱和蒸汽压 101.42 kPa
+	if p := SaturationPressure(100); math.Abs(p-101.42) > 0.05 {
+		t.Errorf("SaturationPressure(100) = %.3f, want ≈ 101.42", p)
+	}
+}
+
+func TestSaturationTemperatureRoundTrip(t *testing.T) {
+	for _, tC := range []float64{1, 40, 60, 100, 150, 200, 300, 370} {
+		if got := SaturationTemperature(SaturationPressure(tC)); math.Abs(got-tC) > 1e-6 {
+			t.Errorf("SaturationTemperature(SaturationPressure(%g)) = %.9f", tC, got)
+		}
+	}
+}
+
+func TestInRange(t *testing.T) {
+	for _, c := range []struct {
+		tC   float64
+		want bool
+	}{
+		{40, true},
+		{0.5, true},
+		{-1, false},
+		{373.946, false},
+		{400, false},
+		{math.Inf(1), false},
+		{math.NaN(), false},
+	} {
+		if got := InRange(c.tC); got != c.want {
+			t.Errorf("InRange(%g) = %v, want %v", c.tC, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,312 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"test/evaluator"
+	"test/sweep"
+)
+
+// 默认扫描：实际流量 40～70 t/h
+var defaultSweepAxis = sweep.Axis{Param: "actual_flow", From: 40, To: 70, Steps: 7}
+
+// 读取扫描参数 prefix_param、prefix_from、prefix_to、prefix_steps，未选择参数时返回 nil
+func parseAxis(src paramSource, prefix string, def *sweep.Axis) *sweep.Axis {
+	name := src.Value(prefix + "_param")
+	if name == "" {
+		return def
+	}
+	a := sweep.Axis{Param: name, Steps: 5}
+	if def != nil && def.Param == name {
+		a = *def
+	}
+	readFiniteFloat(src, prefix+"_from", &a.From)
+	readFiniteFloat(src, prefix+"_to", &a.To)
+	var steps float64
+	readFloat(src, prefix+"_steps", &steps)
+	if steps >= 1 {
+		a.Steps = int(min(steps, sweep.MaxSteps+1)) // 超出上限的由 sweep.Run 报错
+	}
+	return &a
+}
+
+// 读取有限数值，可以为0或负数（如温差、温度的扫描范围），未填写或非法时保持默认值
+func readFiniteFloat(src paramSource, name string, dst *float64) {
+	if v, err := strconv.ParseFloat(src.Value(name), 64); err == nil && !math.IsInf(v, 0) && !math.IsNaN(v) {
+		*dst = v
+	}
+}
+
+// 扫描的基准输入：指定情景时取情景输入，否则按评估页面字段读取（未提供的取默认值）
+func sweepBase(src paramSource) (evaluator.PlantInput, error) {
+	if name := src.Value("scenario"); name != "" {
+		sc, ok := scenarioStore.Get(name)
+		if !ok {
+			return defaultInput(), errors.New("情景不存在: " + name)
+		}
+		return sc.Input, nil
+	}
+	return parseInput(src)
+}
+
+type SweepPageData struct {
+	Error     string
+	Scenario  string          `json:"-"` // 基准情景，为空时使用默认参数
+	Scenarios []string        `json:"-"` // 可选情景
+	Options   []sweep.Option  `json:"-"` // 可扫描参数
+	Sweep     *sweep.Result   `json:",omitempty"`
+	Tornado   []sweep.Tornado `json:",omitempty"`
+	Chart     template.HTML   `json:"-"` // 单参数扫描健康度曲线
+	Tornados  []template.HTML `json:"-"` // 各效龙卷风图
+	X         sweep.Axis      `json:"-"` // 表单回显
+	Y         sweep.Axis      `json:"-"`
+}
+
+// 扫描并计算灵敏度
+func runSweep(src paramSource) (SweepPageData, error) {
+	var data SweepPageData
+	base, err := sweepBase(src)
+	data.Scenario = src.Value("scenario")
+	data.Options = sweep.Options(base)
+	x := parseAxis(src, "x", &defaultSweepAxis)
+	y := parseAxis(src, "y", nil)
+	data.X = *x
+	if y != nil {
+		data.Y = *y
+	}
+	if err != nil {
+		return data, err
+	}
+
+	res, err := sweep.Run(base, *x, y)
+	if err != nil {
+		return data, err
+	}
+	data.Sweep = &res
+	data.Tornado, err = sweep.Sensitivity(base, sweep.MeasuredInputs(base))
+	return data, err
+}
+
+// POST /api/v1/sweep：基准字段与 /api/v1/evaluate 一致（或 scenario 指定情景），
+// 另加 x_param/x_from/x_to/x_steps 及可选的 y_param/y_from/y_to/y_steps
+func apiSweepHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeJSONError(w, http.StatusMethodNotAllowed, "仅支持POST请求")
+		return
+	}
+	var body jsonSource
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeJSONError(w, http.StatusBadRequest, "请求体不是合法的JSON: "+err.Error())
+		return
+	}
+	data, err := runSweep(body)
+	if err != nil {
+		writeJSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, data)
+}
+
+// 健康度曲线配色，按效循环使用
+var chartColors = []string{"#1f77b4", "#ff7f0e", "#2ca02c", "#d62728", "#9467bd", "#8c564b"}
+
+// 单参数扫描：各效健康度随参数变化的折线图（SVG），虚线为良好、中度结垢、严重结垢阈值
+func healthChart(res *sweep.Result) template.HTML {
+	const w, h, left, right, top, bottom = 720.0, 320.0, 50.0, 120.0, 20.0, 40.0
+	pw, ph := w-left-right, h-top-bottom
+	xs := res.X.Values()
+	ymax := 1.2
+	for _, p := range res.Points {
+		for _, e := range p.Effects {
+			ymax = math.Max(ymax, e.Health)
+		}
+	}
+	px := func(v float64) float64 {
+		if len(xs) < 2 || xs[len(xs)-1] == xs[0] {
+			return left + pw/2
+		}
+		return left + pw*(v-xs[0])/(xs[len(xs)-1]-xs[0])
+	}
+	py := func(v float64) float64 { return top + ph*(1-v/ymax) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg width="%.0f" height="%.0f" xmlns="http://www.w3.org/2000/svg" style="background:white;font-size:12px;">`, w, h)
+	fmt.Fprintf(&b, `<rect x="%.0f" y="%.0f" width="%.0f" height="%.0f" fill="none" stroke="#999"/>`, left, top, pw, ph)
+	for _, t := range []float64{evaluator.GoodHealth, evaluator.ModerateFoulingHealth, evaluator.SevereFoulingHealth} {
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#bbb" stroke-dasharray="4,4"/>`, left, py(t), left+pw, py(t))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end">%.1f</text>`, left-4, py(t)+4, t)
+	}
+	fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end">0</text>`, left-4, py(0)+4)
+	for _, v := range xs {
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, px(v), top+ph+16, strconv.FormatFloat(v, 'g', 4, 64))
+	}
+	fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">%s</text>`, left+pw/2, h-4, template.HTMLEscapeString(res.X.Label))
+
+	for i, name := range res.Effects {
+		color := chartColors[i%len(chartColors)]
+		var pts []string
+		for _, p := range res.Points {
+			if p.Error == "" && i < len(p.Effects) {
+				pts = append(pts, fmt.Sprintf("%.1f,%.1f", px(p.X), py(p.Effects[i].Health)))
+			}
+		}
+		fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"/>`, strings.Join(pts, " "), color)
+		ly := top + 16*float64(i+1)
+		fmt.Fprintf(&b, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="2"/>`, left+pw+10, ly-4, left+pw+30, ly-4, color)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f">%s健康度</text>`, left+pw+34, ly, template.HTMLEscapeString(name))
+	}
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+// 龙卷风图（SVG）：以基准健康度为中线，每项测量值偏低、偏高一个误差时的健康度范围，影响最大的在上
+func tornadoChart(t sweep.Tornado) template.HTML {
+	const w, left, right, row = 720.0, 170.0, 60.0, 22.0
+	h := row*float64(len(t.Bars)) + 40
+	lo, hi := t.Health, t.Health
+	for _, bar := range t.Bars {
+		lo = math.Min(lo, math.Min(bar.Low, bar.High))
+		hi = math.Max(hi, math.Max(bar.Low, bar.High))
+	}
+	if hi-lo < 1e-6 {
+		lo, hi = lo-0.01, hi+0.01
+	}
+	pw := w - left - right
+	px := func(v float64) float64 { return left + pw*(v-lo)/(hi-lo) }
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg width="%.0f" height="%.0f" xmlns="http://www.w3.org/2000/svg" style="background:white;font-size:12px;">`, w, h)
+	for i, bar := range t.Bars {
+		y := 10 + row*float64(i)
+		x1, x2 := px(math.Min(bar.Low, bar.High)), px(math.Max(bar.Low, bar.High))
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="end">%s ±%s</text>`, left-6, y+row/2+4, template.HTMLEscapeString(bar.Label), strconv.FormatFloat(bar.Delta, 'g', 3, 64))
+		fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="#f0ad4e"/>`, x1, y+3, math.Max(x2-x1, 1), row-6)
+		fmt.Fprintf(&b, `<text x="%.1f" y="%.1f">%.3f</text>`, x2+4, y+row/2+4, bar.Swing)
+	}
+	fmt.Fprintf(&b, `<line x1="%.1f" y1="5" x2="%.1f" y2="%.1f" stroke="#333"/>`, px(t.Health), px(t.Health), h-25)
+	fmt.Fprintf(&b, `<text x="%.1f" y="%.1f" text-anchor="middle">基准 %.3f</text>`, px(t.Health), h-10, t.Health)
+	b.WriteString(`</svg>`)
+	return template.HTML(b.String())
+}
+
+func sweepHandler(w http.ResponseWriter, r *http.Request) {
+	var data SweepPageData
+	var err error
+	if r.Method == "POST" {
+		data, err = runSweep(formSource{r})
+	} else {
+		data, err = runSweep(jsonSource{})
+	}
+	if err != nil {
+		data.Error = err.Error()
+	}
+	for _, sc := range scenarioStore.List() {
+		data.Scenarios = append(data.Scenarios, sc.Name)
+	}
+	if data.Sweep != nil && data.Sweep.Y == nil {
+		data.Chart = healthChart(data.Sweep)
+	}
+	for _, t := range data.Tornado {
+		data.Tornados = append(data.Tornados, tornadoChart(t))
+	}
+
+	tmpl := `
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="utf-8">
+    <title>参数扫描与灵敏度分析</title>
+    <style>
+        body{font-family:Arial;margin:20px;background:#f8f8f8;}
+        .header{background:#4CAF50;color:white;padding:15px;text-align:center;margin-bottom:20px;}
+        .summary{background:white;padding:15px;border-radius:8px;margin-bottom:20px;box-shadow: 0 2px 4px rgba(0,0,0,0.1);}
+        .error{background:#f8d7da;color:#721c24;padding:10px;border-radius:4px;margin-bottom:20px;}
+        .ok{background:#d4edda !important;}
+        .warn{background:#fff3cd !important;}
+        .bad{background:#f8d7da !important;}
+        table{width:100%;border-collapse:collapse;margin:10px 0;}
+        td,th{border:1px solid #ccc;padding:6px;text-align:center;font-size:14px;}
+        th{background:#e8f4fd;}
+    </style>
+</head>
+<body>
+    <div class="header">
+        <h1>参数扫描与灵敏度分析</h1>
+        <p>在基准输入上改变一个或两个参数批量评估；按仪表测量误差扰动各测量值，排序各效健康度的灵敏度 | <a href="/" style="color:white;">返回评估页面</a></p>
+    </div>
+
+    {{if .Error}}<div class="error">{{.Error}}</div>{{end}}
+
+    <form method="POST" class="summary">
+        <table>
+            <tr>
+                <td>基准输入</td>
+                <td colspan="4">
+                    <select name="scenario">
+                        <option value="">默认参数</option>
+                        {{range .Scenarios}}<option value="{{.}}" {{if eq . $.Scenario}}selected{{end}}>情景：{{.}}</option>{{end}}
+                    </select>
+                </td>
+            </tr>
+            <tr><th></th><th>参数</th><th>起始值</th><th>终止值</th><th>取值个数</th></tr>
+            <tr>
+                <td>参数一</td>
+                <td><select name="x_param">{{range .Options}}<option value="{{.Name}}" {{if eq .Name $.X.Param}}selected{{end}}>{{.Label}}</option>{{end}}</select></td>
+                <td><input name="x_from" value="{{.X.From}}"></td>
+                <td><input name="x_to" value="{{.X.To}}"></td>
+                <td><input name="x_steps" value="{{.X.Steps}}"></td>
+            </tr>
+            <tr>
+                <td>参数二（可选）</td>
+                <td><select name="y_param"><option value="">不扫描</option>{{range .Options}}<option value="{{.Name}}" {{if eq .Name $.Y.Param}}selected{{end}}>{{.Label}}</option>{{end}}</select></td>
+                <td><input name="y_from" value="{{if .Y.Param}}{{.Y.From}}{{end}}"></td>
+                <td><input name="y_to" value="{{if .Y.Param}}{{.Y.To}}{{end}}"></td>
+                <td><input name="y_steps" value="{{if .Y.Param}}{{.Y.Steps}}{{end}}"></td>
+            </tr>
+        </table>
+        <div style="text-align:center;"><input type="submit" value="扫描计算" style="padding:10px 30px;font-size:16px;"></div>
+    </form>
+
+    {{with .Sweep}}
+    <div class="summary">
+        <h3>扫描结果</h3>
+        {{if $.Chart}}{{$.Chart}}{{end}}
+        <table>
+            <tr><th>{{.X.Label}}</th>{{with .Y}}<th>{{.Label}}</th>{{end}}{{range .Effects}}<th>{{.}} Q_run t/h · 健康度 · 状态</th>{{end}}</tr>
+            {{range .Points}}
+            <tr>
+                <td>{{printf "%.3g" .X}}</td>{{if $.Sweep.Y}}<td>{{printf "%.3g" .Y}}</td>{{end}}
+                {{if .Error}}<td colspan="{{len $.Sweep.Effects}}" class="bad">{{.Error}}</td>{{else}}
+                {{range .Effects}}
+                <td {{if gt .Health 0.9}}class="ok"{{else if gt .Health 0.7}}class="warn"{{else}}class="bad"{{end}}>{{printf "%.2f" .Qrun}} · {{printf "%.2f" .Health}} · {{.Status}}</td>
+                {{end}}{{end}}
+            </tr>
+            {{end}}
+        </table>
+    </div>
+    {{end}}
+
+    {{if .Tornado}}
+    <div class="summary">
+        <h3>测量误差灵敏度（龙卷风图）</h3>
+        <div class="info">各测量值分别偏低、偏高一个仪表误差（温度 ±0.5℃、密度 ±0.005 g/cm³、流量 ±1%、进料浓度 ±0.5%，按水合物质量分数计并换算为所选进料浓度单位）时的健康度范围，越靠上的仪表校准越重要</div>
+        {{range $i, $t := .Tornado}}
+        <h4>{{$t.Name}}</h4>
+        {{index $.Tornados $i}}
+        {{end}}
+    </div>
+    {{end}}
+</body>
+</html>
+	`
+	tmplParsed := template.Must(template.New("sweep").Funcs(templateFuncs).Parse(tmpl))
+	tmplParsed.Execute(w, data)
+}
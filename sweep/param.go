@@ -0,0 +1,115 @@
+// Package sweep 参数扫描与灵敏度分析：在基准输入上改变一个或两个参数批量评估，
+// 以及按仪表测量误差扰动各测量值，排序各效健康度的灵敏度（龙卷风图）。
+package sweep
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"test/evaluator"
+)
+
+// 全厂参数：字段名与评估页面表单一致
+var plantParams = []struct {
+	name, label string
+	field       func(in *evaluator.PlantInput) *float64
+}{
+	{"actual_flow", "实际流量", func(in *evaluator.PlantInput) *float64 { return &in.ActualFlow }},
+	{"feed_conc", "进料浓度", func(in *evaluator.PlantInput) *float64 { return &in.FeedConc }},
+	{"feed_temp", "进料温度", func(in *evaluator.PlantInput) *float64 { return &in.FeedTemp }},
+	{"feed_dens", "进料密度", func(in *evaluator.PlantInput) *float64 { return &in.FeedDensity }},
+	{"steam_flow", "生蒸汽流量", func(in *evaluator.PlantInput) *float64 { return &in.SteamFlow }},
+	{"steam_p", "生蒸汽压力", func(in *evaluator.PlantInput) *float64 { return &in.SteamPressure }},
+	{"cond_temp", "冷凝器温度", func(in *evaluator.PlantInput) *float64 { return &in.CondenserTemp }},
+}
+
+// 各效参数：字段名为 前缀+序号，如 dt_set_3
+var effectParams = []struct {
+	prefix, label string
+	field         func(e *evaluator.EffectInput) *float64
+}{
+	{"qnom_", "厂家预设换热能力", func(e *evaluator.EffectInput) *float64 { return &e.Qnom }},
+	{"dt_design_", "预设温差", func(e *evaluator.EffectInput) *float64 { return &e.DtDesign }},
+	{"dt_set_", "计划温差", func(e *evaluator.EffectInput) *float64 { return &e.DtSet }},
+	{"temp_", "出料温度", func(e *evaluator.EffectInput) *float64 { return &e.TempOut }},
+	{"dens_", "出料密度", func(e *evaluator.EffectInput) *float64 { return &e.DensOut }},
+	{"steam_temp_", "加热蒸汽温度", func(e *evaluator.EffectInput) *float64 { return &e.SteamTemp }},
+	{"vapor_p_", "汽室压力", func(e *evaluator.EffectInput) *float64 { return &e.VaporPressure }},
+	{"area_", "换热面积", func(e *evaluator.EffectInput) *float64 { return &e.Area }},
+}
+
+// Field 按表单字段名取得输入中对应数值的指针，如 actual_flow、dt_set_3
+func Field(in *evaluator.PlantInput, name string) (*float64, error) {
+	for _, p := range plantParams {
+		if p.name == name {
+			return p.field(in), nil
+		}
+	}
+	for _, p := range effectParams {
+		if n, ok := strings.CutPrefix(name, p.prefix); ok {
+			i, err := strconv.Atoi(n)
+			if err != nil || i < 1 || i > len(in.Effects) {
+				continue
+			}
+			return p.field(&in.Effects[i-1]), nil
+		}
+	}
+	return nil, fmt.Errorf("不支持扫描的参数: %s", name)
+}
+
+// Label 参数显示名称，如 "III效计划温差"
+func Label(name string) string {
+	for _, p := range plantParams {
+		if p.name == name {
+			return p.label
+		}
+	}
+	for _, p := range effectParams {
+		if n, ok := strings.CutPrefix(name, p.prefix); ok {
+			if i, err := strconv.Atoi(n); err == nil {
+				return evaluator.EffectName(i) + p.label
+			}
+		}
+	}
+	return name
+}
+
+// 复制输入，各效参数不与原输入共用
+func clone(in evaluator.PlantInput) evaluator.PlantInput {
+	in.Effects = append([]evaluator.EffectInput(nil), in.Effects...)
+	in.FeedOrder = append([]int(nil), in.FeedOrder...)
+	return in
+}
+
+// 在输入副本上设置参数
+func with(base evaluator.PlantInput, name string, v float64) (evaluator.PlantInput, error) {
+	in := clone(base)
+	p, err := Field(&in, name)
+	if err != nil {
+		return in, err
+	}
+	*p = v
+	return in, nil
+}
+
+// Option 可扫描参数选项
+type Option struct {
+	Name  string // 字段名
+	Label string // 显示名称
+}
+
+// Options 基准输入下可扫描的全部参数：全厂参数及各效参数
+func Options(in evaluator.PlantInput) []Option {
+	var out []Option
+	for _, p := range plantParams {
+		out = append(out, Option{Name: p.name, Label: p.label})
+	}
+	for i := range in.Effects {
+		for _, p := range effectParams {
+			name := p.prefix + strconv.Itoa(i+1)
+			out = append(out, Option{Name: name, Label: Label(name)})
+		}
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+package sweep
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"test/evaluator"
+)
+
+// 默认仪表测量误差，用于灵敏度分析
+const (
+	TempUncertainty     = 0.5   // 温度 ±℃
+	DensityUncertainty  = 0.005 // 密度 ±g/cm³
+	FlowUncertainty     = 0.01  // 流量 ±相对误差
+	FeedConcUncertainty = 0.5   // 进料浓度 ±%（水合物质量分数，化验误差），按进料浓度单位换算
+)
+
+// Perturbation 一项测量值及其误差
+type Perturbation struct {
+	Param string  // 字段名
+	Label string  // 显示名称
+	Delta float64 // 误差（绝对值）
+}
+
+// MeasuredInputs 基准输入中的测量值及默认误差：流量、进料浓度、各效出料温度和密度，
+// 以及提供了的进料温度、进料密度
+func MeasuredInputs(in evaluator.PlantInput) []Perturbation {
+	out := []Perturbation{
+		{Param: "actual_flow", Delta: in.ActualFlow * FlowUncertainty},
+		{Param: "feed_conc", Delta: feedConcDelta(in)},
+	}
+	if in.FeedTemp > 0 {
+		out = append(out, Perturbation{Param: "feed_temp", Delta: TempUncertainty})
+	}
+	if in.FeedDensity > 0 {
+		out = append(out, Perturbation{Param: "feed_dens", Delta: DensityUncertainty})
+	}
+	for i := range in.Effects {
+		n := fmt.Sprint(i + 1)
+		out = append(out,
+			Perturbation{Param: "temp_" + n, Delta: TempUncertainty},
+			Perturbation{Param: "dens_" + n, Delta: DensityUncertainty},
+		)
+	}
+	for i := range out {
+		out[i].Label = Label(out[i].Param)
+	}
+	return out
+}
+
+// 进料浓度误差换算为输入单位（FeedConcUnit），使不同单位下的灵敏度可比；
+// 换算系数取基准评估中输入浓度与水合物质量分数之比，g/L 时已计入进料密度
+func feedConcDelta(in evaluator.PlantInput) float64 {
+	r, err := evaluator.Evaluate(in)
+	if err != nil || r.FeedConc <= 0 || r.FeedConcInput <= 0 {
+		return FeedConcUncertainty
+	}
+	return FeedConcUncertainty * r.FeedConcInput / r.FeedConc
+}
+
+// Bar 龙卷风图中的一项：测量值分别偏低、偏高一个误差时的健康度
+type Bar struct {
+	Perturbation
+	Low   float64 // 测量值 − 误差时的健康度
+	High  float64 // 测量值 + 误差时的健康度
+	Swing float64 // |High − Low|
+}
+
+// Tornado 单效健康度的灵敏度排序，影响最大的在前
+type Tornado struct {
+	Name   string  // 效名称
+	Health float64 // 基准健康度
+	Bars   []Bar
+}
+
+// Sensitivity 按测量误差逐项扰动，计算各效健康度的变化范围并排序。
+// 扰动后评估失败（如测量值为负）的项不计入。
+func Sensitivity(base evaluator.PlantInput, perturbs []Perturbation) ([]Tornado, error) {
+	r, err := evaluator.Evaluate(base)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Tornado, len(r.Effects))
+	for i, e := range r.Effects {
+		out[i] = Tornado{Name: e.Name, Health: e.Health}
+	}
+
+	for _, p := range perturbs {
+		ptr, err := Field(&base, p.Param)
+		if err != nil {
+			return nil, err
+		}
+		v := *ptr
+		low, errLow := evaluate(base, p.Param, v-p.Delta)
+		high, errHigh := evaluate(base, p.Param, v+p.Delta)
+		if errLow != nil || errHigh != nil {
+			continue
+		}
+		for i := range out {
+			b := Bar{Perturbation: p, Low: low.Effects[i].Health, High: high.Effects[i].Health}
+			b.Swing = math.Abs(b.High - b.Low)
+			out[i].Bars = append(out[i].Bars, b)
+		}
+	}
+	for i := range out {
+		sort.SliceStable(out[i].Bars, func(a, b int) bool { return out[i].Bars[a].Swing > out[i].Bars[b].Swing })
+	}
+	return out, nil
+}
+
+func evaluate(base evaluator.PlantInput, name string, v float64) (evaluator.PlantResult, error) {
+	in, err := with(base, name, v)
+	if err != nil {
+		return evaluator.PlantResult{}, err
+	}
+	return evaluator.Evaluate(in)
+}
@@ -0,0 +1,110 @@
+package sweep
+
+import (
+	"fmt"
+
+	"test/evaluator"
+)
+
+// 扫描规模限制，防止一次请求计算量过大
+const (
+	MaxSteps  = 50  // 每个参数的最大取值个数
+	MaxPoints = 400 // 两个参数时的最大网格点数
+)
+
+// Axis 扫描参数：在 [From, To] 内等间距取 Steps 个值
+type Axis struct {
+	Param string  // 字段名，如 actual_flow、dt_set_3
+	Label string  // 显示名称
+	From  float64 // 起始值
+	To    float64 // 终止值
+	Steps int     // 取值个数（含两端）
+}
+
+// Values 参数取值
+func (a Axis) Values() []float64 {
+	if a.Steps == 1 {
+		return []float64{a.From}
+	}
+	out := make([]float64, a.Steps)
+	for i := range out {
+		out[i] = a.From + (a.To-a.From)*float64(i)/float64(a.Steps-1)
+	}
+	return out
+}
+
+func (a *Axis) validate(base evaluator.PlantInput) error {
+	if _, err := Field(&base, a.Param); err != nil {
+		return err
+	}
+	if a.Steps < 1 || a.Steps > MaxSteps {
+		return fmt.Errorf("%s 取值个数应在1～%d之间", a.Param, MaxSteps)
+	}
+	a.Label = Label(a.Param)
+	return nil
+}
+
+// Point 一个网格点的评估结果
+type Point struct {
+	X, Y    float64       // 参数取值，单参数扫描时 Y 为0
+	Error   string        `json:",omitempty"` // 评估失败原因
+	Effects []PointEffect // 各效结果
+}
+
+// PointEffect 网格点上的单效结果
+type PointEffect struct {
+	Qrun   float64 // 实际蒸发量 t/h
+	Health float64 // 健康度
+	Status string  // 状态
+}
+
+// Result 扫描结果，Points 按 X 优先、Y 其次排列
+type Result struct {
+	X       Axis
+	Y       *Axis    `json:",omitempty"` // 第二个参数，可选
+	Effects []string // 各效名称
+	Points  []Point
+}
+
+// Run 在基准输入上扫描一个或两个参数，y 为 nil 时只扫描 x
+func Run(base evaluator.PlantInput, x Axis, y *Axis) (Result, error) {
+	if err := x.validate(base); err != nil {
+		return Result{}, err
+	}
+	ys := []float64{0}
+	if y != nil {
+		if err := y.validate(base); err != nil {
+			return Result{}, err
+		}
+		if y.Param == x.Param {
+			return Result{}, fmt.Errorf("两个扫描参数不能相同")
+		}
+		if x.Steps*y.Steps > MaxPoints {
+			return Result{}, fmt.Errorf("网格点数 %d 超过上限 %d", x.Steps*y.Steps, MaxPoints)
+		}
+		ys = y.Values()
+	}
+
+	res := Result{X: x, Y: y}
+	for i := range base.Effects {
+		res.Effects = append(res.Effects, evaluator.EffectName(i+1))
+	}
+	for _, xv := range x.Values() {
+		for _, yv := range ys {
+			in, _ := with(base, x.Param, xv)
+			if y != nil {
+				in, _ = with(in, y.Param, yv)
+			}
+			p := Point{X: xv, Y: yv}
+			r, err := evaluator.Evaluate(in)
+			if err != nil {
+				p.Error = err.Error()
+			}
+			for _, e := range r.Effects {
+				p.Effects = append(p.Effects, PointEffect{Qrun: e.Qrun, Health: e.Health, Status: e.Status})
+			}
+			res.Points = append(res.Points, p)
+		}
+	}
+	return res, nil
+}